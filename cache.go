@@ -1,7 +1,6 @@
 package alfred
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -13,13 +12,16 @@ import (
 var ErrCacheExpired = errors.New("cache expired")
 
 type Cache struct {
+	key    string
 	icache internalCacher
+	iindex internalCacher
 	wf     *Workflow
 	maxAge time.Duration
 }
 
 type Cacher interface {
 	MaxAge(time.Duration) CacheControlerOrLoader
+	StaleWhileRevalidate(maxAge time.Duration, refresh RefreshFunc, opts ...RevalidateOption) error
 	Store() error
 	Clear() error
 }
@@ -36,16 +38,16 @@ func (w *Workflow) Cache(key string) Cacher {
 	if key == "" {
 		return &Cache{
 			icache: newNilCache(),
+			iindex: newNilCache(),
 			wf:     w,
 		}
 	}
 
 	return &Cache{
-		icache: &cache{
-			dir:  GetCacheDir(),
-			file: key + ".json",
-		},
-		wf: w,
+		key:    key,
+		icache: newCache(key, JSONCodec),
+		iindex: newCache(key+".index", GobCodec),
+		wf:     w,
 	}
 }
 
@@ -64,15 +66,55 @@ func (c *Cache) Load() error {
 		return err
 	}
 
+	if c.wf.customEnvs.searchIndex {
+		c.loadIndex()
+	}
 	return nil
 }
 
+// loadIndex loads the search index of the loaded items.
+// Filter works without the index if it fails
+func (c *Cache) loadIndex() {
+	c.wf.index = nil
+	in := &iSearchIndex{}
+	if err := c.iindex.load(in); err != nil {
+		c.wf.sLogger().Debugln("failed to load search index:", err)
+		return
+	}
+
+	idx := in.external()
+	if idx.folding != c.wf.customEnvs.indexFolding || !idx.bind(c.wf.items) {
+		c.wf.sLogger().Debugln("search index is outdated")
+		return
+	}
+	c.wf.index = idx
+}
+
 func (c *Cache) Store() error {
 	items := &c.wf.items
-	return c.icache.store(items)
+	if err := c.icache.store(items); err != nil {
+		return err
+	}
+
+	if c.wf.customEnvs.searchIndex {
+		idx := BuildSearchIndex(c.wf.items, c.wf.customEnvs.indexFolding)
+		if err := c.iindex.store(idx.internal()); err != nil {
+			return err
+		}
+		c.wf.index = idx
+	}
+	return nil
 }
 
 func (c *Cache) Clear() error {
+	if err := c.iindex.clear(); err != nil {
+		return err
+	}
+	if c.key != "" {
+		if err := newCache(c.key+revalidateFailureSuffix, JSONCodec).clear(); err != nil {
+			return err
+		}
+	}
 	return c.icache.clear()
 }
 
@@ -85,8 +127,28 @@ type internalCacher interface {
 
 // cache is file level cache
 type cache struct {
-	dir  string
-	file string
+	dir   string
+	file  string
+	codec Codec
+}
+
+// newCache returns a cache of the key in GetCacheDir()
+func newCache(key string, codec Codec) *cache {
+	if codec == nil {
+		codec = JSONCodec
+	}
+	return &cache{
+		dir:   GetCacheDir(),
+		file:  key + codec.Ext(),
+		codec: codec,
+	}
+}
+
+func (c *cache) getCodec() Codec {
+	if c.codec == nil {
+		return JSONCodec
+	}
+	return c.codec
 }
 
 // load read data saved cache into v
@@ -98,36 +160,40 @@ func (c *cache) load(v any) error {
 	}
 	defer f.Close()
 
-	if err = json.NewDecoder(f).Decode(v); err != nil {
+	if err = c.getCodec().Decode(f, v); err != nil {
 		return fmt.Errorf("failed to load data from cache (%s): %w", p, err)
 	}
 
 	return nil
 }
 
-// store save data into cache
+// store save data into cache atomically.
+// The cache file is replaced only if encoding and closing the temporary file succeed
 func (c *cache) store(v any) (err error) {
-	f, err := os.CreateTemp(GetCacheDir(), GetBundleID())
+	if err := os.MkdirAll(c.dir, os.ModePerm); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(c.dir, GetBundleID())
 	if err != nil {
 		return err
 	}
 
-	old := f.Name()
+	tmp := f.Name()
 	defer func() {
-		cerr := f.Close()
-		if err == nil && cerr != nil {
-			err = cerr
-			return
+		if err != nil {
+			_ = os.Remove(tmp)
 		}
-		err = os.Rename(old, c.path())
 	}()
 
-	err = json.NewEncoder(f).Encode(v)
-	if err != nil {
-		return fmt.Errorf("failed to save data into cache (%s): %w", old, err)
+	if err = c.getCodec().Encode(f, v); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to save data into cache (%s): %w", tmp, err)
+	}
+	if err = f.Close(); err != nil {
+		return err
 	}
 
-	return nil
+	return os.Rename(tmp, c.path())
 }
 
 // clear remove cache file if exist
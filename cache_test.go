@@ -1,6 +1,7 @@
 package alfred
 
 import (
+	"os"
 	"testing"
 	"time"
 )
@@ -54,3 +55,56 @@ func TestCache_Load(t *testing.T) {
 		})
 	}
 }
+
+func TestCache_LoadArgs(t *testing.T) {
+	t.Run("load multiple args", func(t *testing.T) {
+		want, err := os.ReadFile(testFilePath("test_scriptfilter_args.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		key := "test-args"
+		wf := testWorkflow().Append(
+			NewItem().Title("single").Arg("arg1").Mod(ModCmd, NewMod().Arg("modarg1")),
+			NewItem().Title("multiple").Args("arg1", "arg2").Mod(ModCmd, NewMod().Args("modarg1", "modarg2")),
+		)
+		if err := wf.Cache(key).Store(); err != nil {
+			t.Fatalf("Cache.Store() error = %v", err)
+		}
+
+		gwf := testWorkflow()
+		if err := gwf.Cache(key).MaxAge(60 * time.Second).Load(); err != nil {
+			t.Errorf("Cache.Load() error = %v", err)
+		}
+
+		got := gwf.Bytes()
+		if diff := DiffOutput(want, got); diff != "" {
+			t.Errorf("-want +got\n%+v", diff)
+		}
+	})
+}
+
+func TestCache_StoreError(t *testing.T) {
+	key := "test-store-error"
+	c := testWorkflow().Append(NewItem().Title("valid")).Cache(key)
+	if err := c.Store(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = c.Clear() })
+
+	invalid := testWorkflow().Append(
+		NewItem().Title("invalid").Mod(ModKey("cmd+shfit"), NewMod().Subtitle("mod")),
+	)
+	if err := invalid.Cache(key).Store(); err == nil {
+		t.Fatal("Store should return an error for invalid items")
+	}
+
+	// the previous cache is kept
+	wf := testWorkflow()
+	if err := wf.Cache(key).MaxAge(time.Minute).Load(); err != nil {
+		t.Fatal(err)
+	}
+	if len(wf.items) != 1 || wf.items[0].title != "valid" {
+		t.Errorf("unexpected items %v", titles(wf.items))
+	}
+}
@@ -0,0 +1,58 @@
+package alfred
+
+import (
+	"compress/gzip"
+	"encoding/gob"
+	"encoding/json"
+	"io"
+)
+
+// Codec encodes and decodes cached data
+type Codec interface {
+	Encode(w io.Writer, v any) error
+	Decode(r io.Reader, v any) error
+	// Ext returns the file extension of the encoded data e.g. ".json"
+	Ext() string
+}
+
+var (
+	// JSONCodec encodes data as JSON. This is the default codec
+	JSONCodec Codec = jsonCodec{}
+	// GobCodec encodes data with encoding/gob
+	GobCodec Codec = gobCodec{}
+	// GzipJSONCodec encodes data as gzip compressed JSON
+	GzipJSONCodec Codec = gzipJSONCodec{}
+)
+
+type jsonCodec struct{}
+
+func (jsonCodec) Encode(w io.Writer, v any) error { return json.NewEncoder(w).Encode(v) }
+func (jsonCodec) Decode(r io.Reader, v any) error { return json.NewDecoder(r).Decode(v) }
+func (jsonCodec) Ext() string                     { return ".json" }
+
+type gobCodec struct{}
+
+func (gobCodec) Encode(w io.Writer, v any) error { return gob.NewEncoder(w).Encode(v) }
+func (gobCodec) Decode(r io.Reader, v any) error { return gob.NewDecoder(r).Decode(v) }
+func (gobCodec) Ext() string                     { return ".gob" }
+
+type gzipJSONCodec struct{}
+
+func (gzipJSONCodec) Encode(w io.Writer, v any) error {
+	zw := gzip.NewWriter(w)
+	if err := json.NewEncoder(zw).Encode(v); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+func (gzipJSONCodec) Decode(r io.Reader, v any) error {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+	return json.NewDecoder(zr).Decode(v)
+}
+
+func (gzipJSONCodec) Ext() string { return ".json.gz" }
@@ -0,0 +1,124 @@
+package alfred
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CommandHandler handles a command. args are the query following the command name
+type CommandHandler func(w *Workflow, args []string) error
+
+// Command is a subcommand of the workflow dispatched by the first word of the query
+type Command struct {
+	name         string
+	aliases      []string
+	description  string
+	initializers []Initializer
+	handler      CommandHandler
+}
+
+// Command registers a command and returns it for further configurations
+func (w *Workflow) Command(name string, h CommandHandler) *Command {
+	c := &Command{
+		name:    name,
+		handler: h,
+	}
+	w.commands = append(w.commands, c)
+	return c
+}
+
+// Alias adds alternative names of the command
+func (c *Command) Alias(aliases ...string) *Command {
+	c.aliases = append(c.aliases, aliases...)
+	return c
+}
+
+// Description adds description displayed as subtitle of the command item
+func (c *Command) Description(s string) *Command {
+	c.description = s
+	return c
+}
+
+// Initializers adds initializers executed only when the command is dispatched
+func (c *Command) Initializers(i ...Initializer) *Command {
+	c.initializers = append(c.initializers, i...)
+	return c
+}
+
+func (c *Command) names() []string {
+	return append([]string{c.name}, c.aliases...)
+}
+
+func (c *Command) is(name string) bool {
+	for _, n := range c.names() {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Command) hasPrefix(prefix string) bool {
+	for _, n := range c.names() {
+		if strings.HasPrefix(strings.ToLower(n), strings.ToLower(prefix)) {
+			return true
+		}
+	}
+	return false
+}
+
+// RunCommand manages workflow environments and dispatches the query to registered commands.
+// If no command matches the first word of the query, commands are listed as autocomplete items.
+// Errors and panics of commands are output as items like Run
+func (w *Workflow) RunCommand(i ...Initializer) (exitCode int) {
+	return w.run(dispatchCommand, i...)
+}
+
+func dispatchCommand(w *Workflow) error {
+	// Alfred passes the whole query as an argument. args are tokenized as Query does
+	tokens := tokenizeQuery(strings.Join(w.Args(), " "))
+	args := make([]string, len(tokens))
+	for idx, t := range tokens {
+		args[idx] = t.text
+	}
+
+	name := ""
+	if len(args) > 0 {
+		name = args[0]
+	}
+
+	for _, c := range w.commands {
+		if !c.is(name) {
+			continue
+		}
+		if err := runInitializers(w, c.initializers); err != nil {
+			return fmt.Errorf("failed to initialize %s command: %w", c.name, err)
+		}
+		if c.handler == nil {
+			return fmt.Errorf("%s command has no handler", c.name)
+		}
+		return c.handler(w, args[1:])
+	}
+
+	candidates := make([]*Command, 0, len(w.commands))
+	for _, c := range w.commands {
+		if c.hasPrefix(name) {
+			candidates = append(candidates, c)
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = w.commands
+	}
+
+	for _, c := range candidates {
+		w.Append(
+			NewItem().
+				Title(c.name).
+				Subtitle(c.description).
+				Autocomplete(c.name + " ").
+				Valid(false),
+		)
+	}
+	w.Output()
+	return nil
+}
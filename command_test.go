@@ -0,0 +1,106 @@
+package alfred
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestWorkflow_RunCommand(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		wantExit  int
+		wantArgs  []string
+		wantItems []string
+	}{
+		{
+			name:      "dispatch command",
+			args:      []string{"start job1 job2"},
+			wantArgs:  []string{"job1", "job2"},
+			wantItems: []string{"started"},
+		},
+		{
+			name:      "dispatch quoted argument",
+			args:      []string{`start "job 1" job2`},
+			wantArgs:  []string{"job 1", "job2"},
+			wantItems: []string{"started"},
+		},
+		{
+			name:      "dispatch quoted argument in multiple arguments",
+			args:      []string{"start", `"job 1"`, "job2"},
+			wantArgs:  []string{"job 1", "job2"},
+			wantItems: []string{"started"},
+		},
+		{
+			name:      "dispatch alias",
+			args:      []string{"run", "job1"},
+			wantArgs:  []string{"job1"},
+			wantItems: []string{"started"},
+		},
+		{
+			name:      "partial match",
+			args:      []string{"st"},
+			wantItems: []string{"start", "stop"},
+		},
+		{
+			name:      "no match lists all commands",
+			args:      []string{"unknown"},
+			wantItems: []string{"start", "stop", "fail", "panic"},
+		},
+		{
+			name:      "handler error",
+			args:      []string{"fail"},
+			wantExit:  1,
+			wantItems: []string{"failed"},
+		},
+		{
+			name:      "handler panic",
+			args:      []string{"panic"},
+			wantExit:  1,
+			wantItems: []string{"panic"},
+		},
+		{
+			name:      "command initializer error",
+			args:      []string{"stop"},
+			wantExit:  1,
+			wantItems: []string{"failed to initialize stop command: initializer error"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outBuf := new(bytes.Buffer)
+			w := testWorkflow(WithOutWriter(outBuf), WithArguments(tt.args...))
+
+			var gotArgs []string
+			w.Command("start", func(w *Workflow, args []string) error {
+				gotArgs = args
+				w.Append(NewItem().Title("started")).Output()
+				return nil
+			}).Alias("run").Description("start jobs")
+			w.Command("stop", func(w *Workflow, args []string) error {
+				return nil
+			}).Initializers(&testInitializer{})
+			w.Command("fail", func(w *Workflow, args []string) error {
+				return errors.New("failed")
+			})
+			w.Command("panic", func(w *Workflow, args []string) error {
+				panic("panic")
+			})
+
+			if got := w.RunCommand(); got != tt.wantExit {
+				t.Errorf("exit code want %d got %d", tt.wantExit, got)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Errorf("args want %v got %v", tt.wantArgs, gotArgs)
+			}
+
+			got := new(ScriptFilter)
+			if err := got.UnmarshalJSON(outBuf.Bytes()); err != nil {
+				t.Fatal(err)
+			}
+			assertTitles(t, tt.wantItems, got.items)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package alfred
+
+// DedupeStrategy decides which item remains when items are duplicated
+type DedupeStrategy int
+
+const (
+	// DedupeKeepFirst keeps the first item of duplicates
+	DedupeKeepFirst DedupeStrategy = iota
+	// DedupeKeepLast keeps the last item of duplicates at the position of the first one
+	DedupeKeepLast
+	// DedupeMerge keeps the first item and adds variables and mods
+	// which only later duplicates have
+	DedupeMerge
+)
+
+// Dedupe removes items with the same uid from the workflow and its sections before output.
+// Items appended after Dedupe are also deduped and the items of the workflow are not modified.
+// Items of sections are duplicates of ones of the workflow and preceding sections.
+// Items without uid are kept. DedupeKeepFirst is used if strategy is not specified
+func (w *Workflow) Dedupe(strategy ...DedupeStrategy) *Workflow {
+	s := DedupeKeepFirst
+	if len(strategy) > 0 {
+		s = strategy[0]
+	}
+	w.customEnvs.dedupe = &s
+	return w
+}
+
+// dedupedItems returns items of the workflow and items of each section to output.
+// They share underlying arrays with the workflow items if Dedupe is not called
+func (w *Workflow) dedupedItems() (Items, []Items) {
+	groups := make([]Items, 0, 1+len(w.sections))
+	groups = append(groups, w.items)
+	for _, section := range w.sections {
+		groups = append(groups, section.items)
+	}
+	if w.customEnvs.dedupe != nil {
+		groups = dedupeGroups(groups, func(item *Item) string { return item.uid }, *w.customEnvs.dedupe)
+	}
+	return groups[0], groups[1:]
+}
+
+// DedupeBy returns items without duplicates of the property value.
+// Items with an empty value are kept
+func (i Items) DedupeBy(property ItemProperty, strategy DedupeStrategy) Items {
+	return i.DedupeFunc(func(item *Item) string {
+		return getItemValue(item, property.String())
+	}, strategy)
+}
+
+// DedupeFunc returns items without duplicates of the key.
+// Items with an empty key are kept
+func (i Items) DedupeFunc(key func(*Item) string, strategy DedupeStrategy) Items {
+	return dedupeGroups([]Items{i}, key, strategy)[0]
+}
+
+// dedupeGroups returns groups without duplicates of the key across the groups.
+// A remaining item is placed at the position of the first one of duplicates
+func dedupeGroups(groups []Items, key func(*Item) string, strategy DedupeStrategy) []Items {
+	type position struct{ group, idx int }
+	ret := make([]Items, len(groups))
+	positions := make(map[string]position)
+	for g, group := range groups {
+		items := make(Items, 0, cap(group))
+		for _, item := range group {
+			k := key(item)
+			if k == "" {
+				items = append(items, item)
+				continue
+			}
+
+			pos, ok := positions[k]
+			if !ok {
+				positions[k] = position{group: g, idx: len(items)}
+				items = append(items, item)
+				continue
+			}
+
+			dst := items
+			if pos.group != g {
+				dst = ret[pos.group]
+			}
+			switch strategy {
+			case DedupeKeepLast:
+				dst[pos.idx] = item
+			case DedupeMerge:
+				dst[pos.idx] = mergeItem(dst[pos.idx], item)
+			}
+		}
+		ret[g] = items
+	}
+	return ret
+}
+
+// mergeItem returns a copy of dst with variables and mods only src has
+func mergeItem(dst, src *Item) *Item {
+	merged := *dst
+	merged.variables = make(Variables, len(dst.variables)+len(src.variables))
+	for k, v := range src.variables {
+		merged.variables[k] = v
+	}
+	for k, v := range dst.variables {
+		merged.variables[k] = v
+	}
+
+	merged.mods = make(Mods, len(dst.mods)+len(src.mods))
+	for k, v := range src.mods {
+		merged.mods[k] = v
+	}
+	for k, v := range dst.mods {
+		merged.mods[k] = v
+	}
+
+	if len(merged.variables) == 0 {
+		merged.variables = nil
+	}
+	if len(merged.mods) == 0 {
+		merged.mods = nil
+	}
+	return &merged
+}
@@ -0,0 +1,119 @@
+package alfred
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestItems_DedupeBy(t *testing.T) {
+	input := Items{
+		NewItem().Title("cache1").UID("uid1").Variable("key1", "cache").Mod(ModCmd, NewMod().Arg("cache")),
+		NewItem().Title("no uid"),
+		NewItem().Title("live1").UID("uid1").Variable("key1", "live").Variable("key2", "live").Mod(ModAlt, NewMod().Arg("live")),
+		NewItem().Title("live2").UID("uid2"),
+		NewItem().Title("no uid"),
+	}
+	tests := []struct {
+		name     string
+		strategy DedupeStrategy
+		want     Items
+	}{
+		{
+			name:     "keep first",
+			strategy: DedupeKeepFirst,
+			want:     Items{input[0], input[1], input[3], input[4]},
+		},
+		{
+			name:     "keep last",
+			strategy: DedupeKeepLast,
+			want:     Items{input[2], input[1], input[3], input[4]},
+		},
+		{
+			name:     "merge variables and mods",
+			strategy: DedupeMerge,
+			want: Items{
+				NewItem().Title("cache1").UID("uid1").
+					Variable("key1", "cache").Variable("key2", "live").
+					Mod(ModCmd, NewMod().Arg("cache")).Mod(ModAlt, NewMod().Arg("live")),
+				input[1], input[3], input[4],
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := input.DedupeBy(ItemPropertyUID, tt.strategy)
+			if diff := Diff(tt.want, got); diff != "" {
+				t.Errorf("-want +got\n%+v", diff)
+			}
+			assertTitles(t, titles(tt.want), got)
+		})
+	}
+}
+
+func TestWorkflow_Dedupe(t *testing.T) {
+	outputTitles := func(t *testing.T, w *Workflow) []string {
+		t.Helper()
+		got := new(ScriptFilter)
+		if err := got.UnmarshalJSON(w.Bytes()); err != nil {
+			t.Fatal(err)
+		}
+		return titles(got.items)
+	}
+
+	t.Run("dedupe by uid before output", func(t *testing.T) {
+		w := testWorkflow().Append(NewItem().Title("title1").UID("uid"))
+		w.Dedupe()
+		w.Append(NewItem().Title("title2").UID("uid"))
+
+		if got := outputTitles(t, w); !reflect.DeepEqual(got, []string{"title1"}) {
+			t.Errorf("want [title1] got %v", got)
+		}
+		if got := titles(w.items); !reflect.DeepEqual(got, []string{"title1", "title2"}) {
+			t.Errorf("items should not be modified: got %v", got)
+		}
+	})
+
+	t.Run("dedupe sections", func(t *testing.T) {
+		w := testWorkflow().Append(NewItem().Title("item").UID("uid"))
+		w.AppendSection(
+			NewSection("section1").Items(
+				NewItem().Title("s1-1").UID("uid"),
+				NewItem().Title("s1-2").UID("s1"),
+				NewItem().Title("s1-3").UID("s1"),
+			),
+			NewSection("section2").Items(
+				NewItem().Title("s2-1").UID("s1"),
+				NewItem().Title("s2-2"),
+			),
+		)
+		w.Dedupe(DedupeKeepLast)
+
+		want := []string{"s1-1", "section1", "s2-1", "section2", "s2-2"}
+		if got := outputTitles(t, w); !reflect.DeepEqual(got, want) {
+			t.Errorf("want %v got %v", want, got)
+		}
+	})
+
+	t.Run("dedupe before pagination and validation", func(t *testing.T) {
+		w := testWorkflow(WithMaxResults(1), WithPagination()).Append(
+			NewItem().Title("title1").UID("uid"),
+			NewItem().Title("title2").UID("uid"),
+		)
+		w.Dedupe()
+
+		if got := outputTitles(t, w); !reflect.DeepEqual(got, []string{"title1"}) {
+			t.Errorf("want [title1] got %v", got)
+		}
+		if errs := w.Validate(); errs != nil {
+			t.Errorf("unexpected errors %v", errs)
+		}
+	})
+}
+
+func titles(items Items) []string {
+	ret := make([]string, len(items))
+	for idx, item := range items {
+		ret[idx] = item.title
+	}
+	return ret
+}
@@ -45,10 +45,18 @@ func Diff(want, got interface{}) string {
 		w := want.(*Text).internal()
 		g := got.(*Text).internal()
 		return cmp.Diff(w, g)
+	case *Action:
+		w := want.(*Action).internal()
+		g := got.(*Action).internal()
+		return cmp.Diff(w, g)
 	case *Icon:
 		w := want.(*Icon).internal()
 		g := got.(*Icon).internal()
 		return cmp.Diff(w, g)
+	case *CacheControl:
+		w := want.(*CacheControl).internal()
+		g := got.(*CacheControl).internal()
+		return cmp.Diff(w, g)
 	case *Item:
 		w := want.(*Item).internal()
 		g := got.(*Item).internal()
@@ -67,6 +75,14 @@ func Diff(want, got interface{}) string {
 		g := got.(*ScriptFilter).internal()
 		sortItems(w.Items, g.Items)
 		return cmp.Diff(w, g)
+	case *RunScriptOutput:
+		w := want.(*RunScriptOutput).internal()
+		g := got.(*RunScriptOutput).internal()
+		return cmp.Diff(w, g)
+	case *TextView:
+		w := want.(*TextView).internal()
+		g := got.(*TextView).internal()
+		return cmp.Diff(w, g)
 	default:
 		return fmt.Sprintf("unsupported type in want/got %v", val)
 	}
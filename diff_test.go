@@ -53,7 +53,7 @@ var items04 = Items{
 	&Item{
 		title:        "title",
 		subtitle:     "subtitle",
-		arg:          "arg",
+		arg:          []string{"arg"},
 		autocomplete: "autocomplete",
 		variables: map[string]string{
 			"key": "value",
@@ -65,7 +65,7 @@ var items04 = Items{
 		mods: map[ModKey]*Mod{
 			ModCtrl: {
 				subtitle: "modctrl",
-				arg:      "arg",
+				arg:      []string{"arg"},
 				variables: map[string]string{
 					"key": "value",
 				},
@@ -87,13 +87,13 @@ var items05 = Items{
 	{
 		title:    "title1",
 		subtitle: "subtitle1",
-		arg:      "arg1",
+		arg:      []string{"arg1"},
 		uid:      "uid1",
 	},
 	{
 		title:    "title2",
 		subtitle: "subtitle2",
-		arg:      "arg2",
+		arg:      []string{"arg2"},
 		uid:      "uid2",
 	},
 }
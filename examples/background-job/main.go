@@ -3,7 +3,6 @@ package main
 import (
 	"os"
 	"os/exec"
-	"strings"
 
 	"github.com/konoui/go-alfred"
 )
@@ -25,24 +24,16 @@ func init() {
 }
 
 func main() {
-	os.Exit(awf.RunSimple(run))
-}
-
-func run() error {
-	if strings.EqualFold(getQuery(os.Args, 1), "start") {
+	awf.Command("start", func(*alfred.Workflow, []string) error {
 		return startJobs()
-	}
-	if strings.EqualFold(getQuery(os.Args, 1), "kill") {
+	}).Description("start the background job")
+	awf.Command("kill", func(*alfred.Workflow, []string) error {
 		return terminateJob(jobName)
-	}
-	return listJobs()
-}
-
-func getQuery(args []string, idx int) string {
-	if len(args) > idx {
-		return args[idx]
-	}
-	return ""
+	}).Description("kill the background job")
+	awf.Command("list", func(*alfred.Workflow, []string) error {
+		return listJobs()
+	}).Alias("ls").Description("list running jobs")
+	os.Exit(awf.RunCommand())
 }
 
 func startJobs() error {
@@ -0,0 +1,102 @@
+package alfred
+
+import (
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/width"
+)
+
+// Folding normalizes the query and item values before matching.
+// Foldings can be combined with bitwise OR
+type Folding int
+
+const (
+	// FoldNFKC applies NFKC normalization e.g. "ｶ" and "カ", "①" and "1"
+	FoldNFKC Folding = 1 << iota
+	// FoldWidth folds full-width and half-width characters e.g. "ＡＢＣ" and "ABC"
+	FoldWidth
+	// FoldDiacritics removes diacritical marks e.g. "é" and "e"
+	FoldDiacritics
+	// FoldCase applies Unicode case folding e.g. "Straße" and "STRASSE"
+	FoldCase
+	// FoldKana folds hiragana into katakana e.g. "ひらがな" and "ヒラガナ"
+	FoldKana
+	// FoldAll applies all foldings
+	FoldAll = FoldNFKC | FoldWidth | FoldDiacritics | FoldCase | FoldKana
+)
+
+// WithFolding folds both the query and item values before matching.
+// Returned items are not modified
+func WithFolding(f Folding) FilterOption {
+	return func(c *filterConfig) {
+		c.folding = f
+	}
+}
+
+func (f Folding) has(flag Folding) bool {
+	return f&flag != 0
+}
+
+// Fold returns s folded
+func (f Folding) Fold(s string) string {
+	if fold := f.folder(); fold != nil {
+		return fold(s)
+	}
+	return s
+}
+
+// folder returns a function folding strings. A transformer is not safe for concurrent use
+func (f Folding) folder() func(string) string {
+	t := f.transformer()
+	if t == nil {
+		return nil
+	}
+	return func(s string) string {
+		ret, _, err := transform.String(t, s)
+		if err != nil {
+			return s
+		}
+		return ret
+	}
+}
+
+func (f Folding) transformer() transform.Transformer {
+	var ts []transform.Transformer
+	if f.has(FoldNFKC) {
+		ts = append(ts, norm.NFKC)
+	}
+	if f.has(FoldWidth) {
+		ts = append(ts, width.Fold)
+	}
+	if f.has(FoldDiacritics) {
+		ts = append(ts, norm.NFD, runes.Remove(runes.Predicate(isDiacritic)), norm.NFC)
+	}
+	if f.has(FoldKana) {
+		ts = append(ts, runes.Map(hiraganaToKatakana))
+	}
+	if f.has(FoldCase) {
+		ts = append(ts, cases.Fold())
+	}
+	if len(ts) == 0 {
+		return nil
+	}
+	return transform.Chain(ts...)
+}
+
+// isDiacritic reports whether r is a nonspacing mark except kana voiced sound marks
+// so that "ガ" is not folded into "カ"
+func isDiacritic(r rune) bool {
+	return unicode.Is(unicode.Mn, r) && r != '\u3099' && r != '\u309A'
+}
+
+// hiraganaToKatakana maps hiragana from ぁ to ゖ and ゝ, ゞ into katakana
+func hiraganaToKatakana(r rune) rune {
+	if (r >= 'ぁ' && r <= 'ゖ') || r == 'ゝ' || r == 'ゞ' {
+		return r + 'ァ' - 'ぁ'
+	}
+	return r
+}
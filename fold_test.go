@@ -0,0 +1,59 @@
+package alfred
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFolding_Fold(t *testing.T) {
+	tests := []struct {
+		name    string
+		folding Folding
+		in      string
+		want    string
+	}{
+		{name: "no folding", folding: 0, in: "Café", want: "Café"},
+		{name: "nfkc", folding: FoldNFKC, in: "ｶﾀｶﾅ①", want: "カタカナ1"},
+		{name: "width", folding: FoldWidth, in: "ＡＢＣ１２３", want: "ABC123"},
+		{name: "diacritics", folding: FoldDiacritics, in: "Crème brûlée", want: "Creme brulee"},
+		{name: "case", folding: FoldCase, in: "Straße GO", want: "strasse go"},
+		{name: "kana", folding: FoldKana, in: "ひらがなゞ", want: "ヒラガナヾ"},
+		{name: "all", folding: FoldAll, in: "Ｃａｆé ｶﾞｲﾄﾞ がいど", want: "cafe ガイド ガイド"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.folding.Fold(tt.in); got != tt.want {
+				t.Errorf("want %q got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestItems_FilterWithFolding(t *testing.T) {
+	items := Items{
+		NewItem().Title("Café"),
+		NewItem().Title("ＧＯ－ＡＬＦＲＥＤ"),
+		NewItem().Title("カタカナ"),
+		NewItem().Title("tea"),
+	}
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{query: "cafe", want: []string{"Café"}},
+		{query: "go-alfred", want: []string{"ＧＯ－ＡＬＦＲＥＤ"}},
+		{query: "かたかな", want: []string{"カタカナ"}},
+		{query: "ｶﾀｶﾅ", want: []string{"カタカナ"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := items.Filter(tt.query); len(got) != 0 {
+				t.Errorf("unexpected match without folding %v", titles(got))
+			}
+			got := items.Filter(tt.query, WithFolding(FoldAll))
+			if !reflect.DeepEqual(titles(got), tt.want) {
+				t.Errorf("want %v got %v", tt.want, titles(got))
+			}
+		})
+	}
+}
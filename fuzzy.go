@@ -2,6 +2,8 @@ package alfred
 
 import (
 	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/sahilm/fuzzy"
 )
@@ -13,6 +15,8 @@ const (
 	ItemPropertySubtitle
 	ItemPropertyArg
 	ItemPropertyUID
+	ItemPropertyMatch
+	ItemPropertyAutocomplete
 )
 
 func (p ItemProperty) String() string {
@@ -26,28 +30,101 @@ func (p ItemProperty) String() string {
 		field = "arg"
 	case ItemPropertyUID:
 		field = "uid"
+	case ItemPropertyMatch:
+		field = "match"
+	case ItemPropertyAutocomplete:
+		field = "autocomplete"
 	}
 	return field
 }
 
-// Filter by item title with fuzzy
-func (w *Workflow) Filter(query string) *Workflow {
-	w.items = w.items.Filter(query)
+// ParseItemProperty returns the property of the name such as "title"
+func ParseItemProperty(name string) (ItemProperty, bool) {
+	for _, p := range []ItemProperty{
+		ItemPropertyTitle,
+		ItemPropertySubtitle,
+		ItemPropertyArg,
+		ItemPropertyUID,
+		ItemPropertyMatch,
+		ItemPropertyAutocomplete,
+	} {
+		if strings.EqualFold(p.String(), name) {
+			return p, true
+		}
+	}
+	return 0, false
+}
+
+// FilterOption configures filtering
+type FilterOption func(*filterConfig)
+
+type filterConfig struct {
+	matcher Matcher
+	fields  []fieldWeight
+	boost   func(*Item) int
+	folding Folding
+	index   *SearchIndex
+}
+
+type fieldWeight struct {
+	property ItemProperty
+	weight   int
+}
+
+// WithMatcher changes the matcher used for filtering. FuzzyMatcher is the default
+func WithMatcher(m Matcher) FilterOption {
+	return func(c *filterConfig) {
+		c.matcher = m
+	}
+}
+
+// WithField adds the item property searched with the weight.
+// Scores of matched fields are multiplied by the weights and summed up.
+// Title falls back to match of the item if it is set as Alfred does.
+// Only title is searched if no field is specified
+func WithField(property ItemProperty, weight int) FilterOption {
+	return func(c *filterConfig) {
+		c.fields = append(c.fields, fieldWeight{property: property, weight: weight})
+	}
+}
+
+func newFilterConfig(opts ...FilterOption) *filterConfig {
+	c := &filterConfig{}
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		opt(c)
+	}
+	return c
+}
+
+// Filter by item title with fuzzy or the matcher specified by WithMatcher.
+// The search index loaded by Cache.Load is used if WithSearchIndex is enabled
+func (w *Workflow) Filter(query string, opts ...FilterOption) *Workflow {
+	if w.index != nil {
+		opts = append([]FilterOption{WithIndex(w.index)}, opts...)
+	}
+	w.items = w.items.Filter(query, opts...)
+	w.eachSection(func(i Items) Items { return i.Filter(query, opts...) })
 	return w
 }
 
 func (w *Workflow) FilterByItemProperty(f func(s string) bool, property ItemProperty) *Workflow {
-	items := make(Items, 0, cap(w.items))
-	for _, item := range w.items {
+	w.items = filterByItemProperty(w.items, f, property)
+	w.eachSection(func(i Items) Items { return filterByItemProperty(i, f, property) })
+	return w
+}
+
+func filterByItemProperty(in Items, f func(s string) bool, property ItemProperty) Items {
+	items := make(Items, 0, cap(in))
+	for _, item := range in {
 		v := getItemValue(item, property.String())
 		if f(v) {
 			items = append(items, item)
 		}
 	}
-
-	// update
-	w.items = items
-	return w
+	return items
 }
 
 func getItemValue(item *Item, field string) string {
@@ -59,13 +136,30 @@ func getItemValue(item *Item, field string) string {
 		return ""
 	}
 
-	v := rv.FieldByName(f.Name).String()
-	return v
+	v := rv.FieldByName(f.Name)
+	if v.Kind() == reflect.Slice {
+		// multiple args are joined with space
+		values := make([]string, v.Len())
+		for idx := range values {
+			values[idx] = v.Index(idx).String()
+		}
+		return strings.Join(values, " ")
+	}
+	return v.String()
+}
+
+// searchValue returns the value of the property. title falls back to match if it is set
+func searchValue(item *Item, property ItemProperty) string {
+	if property == ItemPropertyTitle && item.match != "" {
+		return item.match
+	}
+	return getItemValue(item, property.String())
 }
 
-// String retruns a title of Item for fuzzy interface
+// String retruns a title of Item for fuzzy interface.
+// match is returned instead of title if it is set as Alfred does
 func (i Items) String(idx int) string {
-	return i[idx].title
+	return searchValue(i[idx], ItemPropertyTitle)
 }
 
 // Len returns length of Items for fuzzy interface
@@ -73,16 +167,118 @@ func (i Items) Len() int {
 	return len(i)
 }
 
-// Filter searches items using query
-func (i Items) Filter(query string) Items {
+// Filter searches items using query.
+// Items are sorted by the score in descending order
+func (i Items) Filter(query string, opts ...FilterOption) Items {
+	c := newFilterConfig(opts...)
 	if query == "" {
+		if c.boost != nil {
+			return i.boostOnly(c.boost)
+		}
 		return i
 	}
-	results := fuzzy.FindFrom(query, i)
-	items := make(Items, results.Len())
-	for idx, r := range results {
-		items[idx] = i[r.Index]
+
+	candidates, titles := i, []string(nil)
+	if c.indexable(i) {
+		candidates, titles = c.index.filterIndexed(c.folding.Fold(query))
+	}
+
+	if c.matcher == nil && len(c.fields) == 0 && c.boost == nil && c.folding == 0 {
+		results := fuzzy.FindFrom(query, candidates)
+		items := make(Items, results.Len())
+		for idx, r := range results {
+			items[idx] = candidates[r.Index]
+		}
+		return items
+	}
+
+	return candidates.filterWith(query, c, titles)
+}
+
+// indexable returns true if the index is built from items and
+// pre-filtering by characters of the query does not drop matched items
+func (c *filterConfig) indexable(items Items) bool {
+	if c.matcher != nil || !c.index.usable(items) || c.index.folding != c.folding {
+		return false
+	}
+	for _, f := range c.fields {
+		if f.property != ItemPropertyTitle {
+			return false
+		}
+	}
+	return true
+}
+
+type scoredItem struct {
+	item  *Item
+	score int
+}
+
+// filterWith scores items by the matcher and fields.
+// titles are folded titles of items from the index or nil
+func (i Items) filterWith(query string, c *filterConfig, titles []string) Items {
+	matcher := c.matcher
+	if matcher == nil {
+		matcher = FuzzyMatcher()
+	}
+	fields := c.fields
+	if len(fields) == 0 {
+		fields = []fieldWeight{{property: ItemPropertyTitle, weight: 1}}
+	}
+	fold := c.folding.folder()
+	if fold != nil {
+		query = fold(query)
+	}
+
+	scored := make([]scoredItem, 0, len(i))
+	for pos, item := range i {
+		total, matched := 0, false
+		for _, f := range fields {
+			var v string
+			if titles != nil && f.property == ItemPropertyTitle {
+				v = titles[pos]
+			} else {
+				v = searchValue(item, f.property)
+				if fold != nil {
+					v = fold(v)
+				}
+			}
+			score, ok := matcher.Match(query, v)
+			if !ok {
+				continue
+			}
+			total += score * f.weight
+			matched = true
+		}
+		if !matched {
+			continue
+		}
+		if c.boost != nil {
+			total += c.boost(item)
+		}
+		scored = append(scored, scoredItem{item: item, score: total})
 	}
 
+	return sortScored(scored)
+}
+
+// boostOnly sorts items by the boost scores
+func (i Items) boostOnly(boost func(*Item) int) Items {
+	scored := make([]scoredItem, len(i))
+	for idx, item := range i {
+		scored[idx] = scoredItem{item: item, score: boost(item)}
+	}
+	return sortScored(scored)
+}
+
+func sortScored(scored []scoredItem) Items {
+	sort.SliceStable(scored, func(a, b int) bool {
+		return scored[a].score > scored[b].score
+	})
+
+	items := make(Items, len(scored))
+	for idx, s := range scored {
+		items[idx] = s.item
+	}
 	return items
 }
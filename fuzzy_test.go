@@ -1,6 +1,7 @@
 package alfred
 
 import (
+	"math/rand"
 	"reflect"
 	"strings"
 	"testing"
@@ -119,3 +120,111 @@ func TestWorkflow_FilterByItemProperty(t *testing.T) {
 		})
 	}
 }
+
+func TestItems_FilterWithFields(t *testing.T) {
+	items := Items{
+		NewItem().Title("alpha").Subtitle("go workflow"),
+		NewItem().Title("go-alfred").Subtitle("library"),
+		NewItem().Title("beta").Match("go beta"),
+		NewItem().Title("gamma").Autocomplete("go gamma"),
+		NewItem().Title("delta"),
+	}
+	tests := []struct {
+		name string
+		opts []FilterOption
+		want []string
+	}{
+		{
+			name: "title falls back to match",
+			opts: []FilterOption{WithField(ItemPropertyTitle, 1), WithMatcher(PrefixMatcher())},
+			want: []string{"beta", "go-alfred"},
+		},
+		{
+			name: "weighted fields",
+			opts: []FilterOption{
+				WithField(ItemPropertyTitle, 1),
+				WithField(ItemPropertySubtitle, 10),
+				WithField(ItemPropertyAutocomplete, 1),
+				WithMatcher(PrefixMatcher()),
+			},
+			want: []string{"alpha", "beta", "gamma", "go-alfred"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := items.Filter("go", tt.opts...)
+			if !reflect.DeepEqual(titles(got), tt.want) {
+				t.Errorf("want %v got %v", tt.want, titles(got))
+			}
+		})
+	}
+}
+
+func TestParseItemProperty(t *testing.T) {
+	for _, p := range []ItemProperty{
+		ItemPropertyTitle, ItemPropertySubtitle, ItemPropertyArg,
+		ItemPropertyUID, ItemPropertyMatch, ItemPropertyAutocomplete,
+	} {
+		got, ok := ParseItemProperty(strings.ToUpper(p.String()))
+		if !ok || got != p {
+			t.Errorf("ParseItemProperty(%s) = %v, %v", p, got, ok)
+		}
+	}
+}
+
+var benchmarkWords = []string{
+	"alfred", "workflow", "golang", "kubernetes", "docker", "github", "terminal", "safari",
+	"finder", "notes", "calendar", "reminder", "music", "photos", "preview", "xcode",
+}
+
+func benchmarkFilterItems(n int) Items {
+	r := rand.New(rand.NewSource(1))
+	items := make(Items, n)
+	for i := range items {
+		words := make([]string, 3)
+		for j := range words {
+			words[j] = benchmarkWords[r.Intn(len(benchmarkWords))]
+		}
+		items[i] = NewItem().Title(strings.Join(words, " "))
+	}
+	return items
+}
+
+func BenchmarkFilter(b *testing.B) {
+	items := benchmarkFilterItems(50000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		items.Filter("kbx")
+	}
+}
+
+func BenchmarkFilterIndexed(b *testing.B) {
+	items := benchmarkFilterItems(50000)
+	idx := BuildSearchIndex(items, 0)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		items.Filter("kbx", WithIndex(idx))
+	}
+}
+
+func TestItems_FilterHonorsMatch(t *testing.T) {
+	items := Items{
+		NewItem().Title("Google Chrome").Match("browser web"),
+		NewItem().Title("Safari"),
+	}
+	for name, opts := range map[string][]FilterOption{
+		"default":    nil,
+		"folding":    {WithFolding(FoldCase)},
+		"title":      {WithField(ItemPropertyTitle, 1)},
+		"with index": {WithIndex(BuildSearchIndex(items, 0))},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := items.Filter("browser", opts...); !reflect.DeepEqual(titles(got), []string{"Google Chrome"}) {
+				t.Errorf("match should be searched: got %v", titles(got))
+			}
+			if got := items.Filter("chrome", opts...); len(got) != 0 {
+				t.Errorf("title should not be searched if match is set: got %v", titles(got))
+			}
+		})
+	}
+}
@@ -27,7 +27,16 @@ func (w *Workflow) OnInitialize(initializers ...Initializer) error {
 	defer func() { w.markers.initDone = true }()
 
 	w.actions = append(w.actions, initializers...)
-	for _, i := range w.actions {
+	actions := w.actions
+	if w.customEnvs.usage {
+		// the recorder is not in actions so that WithInitializers does not drop it
+		actions = append(actions[:len(actions):len(actions)], new(usageRecorder))
+	}
+	return runInitializers(w, actions)
+}
+
+func runInitializers(w *Workflow, initializers []Initializer) error {
+	for _, i := range initializers {
 		if i == nil {
 			continue
 		}
@@ -68,23 +68,23 @@ func (e *embedIcon) Down() {
 }
 
 func (e *embedIcon) IconTrash() *alfred.Icon {
-	return e.getIcon("TrashIcon.icns", e.fallback[trash])
+	return e.getIcon(alfred.SystemIconTrash, e.fallback[trash])
 }
 
 func (e *embedIcon) IconAlertNote() *alfred.Icon {
-	return e.getIcon("AlertNoteIcon.icns", e.fallback[alertNote])
+	return e.getIcon(alfred.SystemIconAlertNote, e.fallback[alertNote])
 }
 
 func (e *embedIcon) IconCaution() *alfred.Icon {
-	return e.getIcon("AlertCautionBadgeIcon.icns", e.fallback[caution])
+	return e.getIcon(alfred.SystemIconAlertCautionBadge, e.fallback[caution])
 }
 
 func (e *embedIcon) IconAlertStop() *alfred.Icon {
-	return e.getIcon("AlertStopIcon.icns", e.fallback[alertStop])
+	return e.getIcon(alfred.SystemIconAlertStop, e.fallback[alertStop])
 }
 
 func (e *embedIcon) IconExec() *alfred.Icon {
-	return e.getIcon("ExecutableBinaryIcon.icns", e.fallback[execp])
+	return e.getIcon(alfred.SystemIconExecutableBinary, e.fallback[execp])
 }
 
 func (e *embedIcon) getIcon(filename string, fallback *alfred.Icon) *alfred.Icon {
@@ -8,6 +8,7 @@ import (
 var (
 	_ Appender      = (*Workflow)(nil)
 	_ Outputer      = (*Workflow)(nil)
+	_ Validator     = (*Workflow)(nil)
 	_ Clearer       = (*Workflow)(nil)
 	_ Setter        = (*Workflow)(nil)
 	_ IO            = (*Workflow)(nil)
@@ -26,11 +27,15 @@ type Appender interface {
 	Rerun(i Rerun) *Workflow
 	Variables(Variables) *Workflow
 	Variable(key, value string) *Workflow
+	CacheControl(c *CacheControl) *Workflow
+	SkipKnowledge(b bool) *Workflow
 }
 
 type Setter interface {
 	SetEmptyWarning(title, subtitle string) *Workflow
 	SetSystemInfo(i *Item) *Workflow
+	SetRunScriptOutput(o *RunScriptOutput) *Workflow
+	SetTextView(t *TextView) *Workflow
 }
 
 type Outputer interface {
@@ -38,6 +43,10 @@ type Outputer interface {
 	Fatal(title, subtitle string)
 }
 
+type Validator interface {
+	Validate() ValidationErrors
+}
+
 type Clearer interface {
 	Clear() *Workflow
 	IsEmpty() bool
@@ -59,6 +68,7 @@ type LogGetter interface {
 type Runner interface {
 	RunSimple(fn func() error, i ...Initializer) (exitCode int)
 	Run(fn func(*Workflow) error, i ...Initializer) (exitCode int)
+	RunCommand(i ...Initializer) (exitCode int)
 }
 
 type ArgGetter interface {
@@ -66,8 +76,10 @@ type ArgGetter interface {
 }
 
 type Filter interface {
-	Filter(query string) *Workflow
+	Filter(query string, opts ...FilterOption) *Workflow
 	FilterByItemProperty(f func(s string) bool, property ItemProperty) *Workflow
+	Sort(property ItemProperty, properties ...ItemProperty) *Workflow
+	FilterQuery(q *Query, opts ...FilterOption) *Workflow
 }
 
 type OptionUpdater interface {
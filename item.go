@@ -21,15 +21,18 @@ type Item struct {
 	uid          string
 	title        string
 	subtitle     string
-	arg          string
+	arg          []string
 	icon         *Icon
 	autocomplete string
-	typ          string
+	typ          ItemType
 	valid        *bool
 	match        string
 	mods         Mods
 	text         *Text
 	quicklookURL string
+	action       *Action
+	// header is true for a section header which does nothing intentionally
+	header bool
 }
 
 // NewItem generates new item
@@ -74,7 +77,13 @@ func (i *Item) Subtitle(s string) *Item {
 
 // Arg adds arg
 func (i *Item) Arg(arg string) *Item {
-	i.arg = arg
+	i.arg = newArgs(arg)
+	return i
+}
+
+// Args adds multiple arguments passed out as an array
+func (i *Item) Args(args ...string) *Item {
+	i.arg = newArgs(args...)
 	return i
 }
 
@@ -90,6 +99,12 @@ func (i *Item) Autocomplete(s string) *Item {
 	return i
 }
 
+// Type adds item type
+func (i *Item) Type(t ItemType) *Item {
+	i.typ = t
+	return i
+}
+
 // Valid adds valid
 func (i *Item) Valid(b bool) *Item {
 	i.valid = &b
@@ -115,7 +130,7 @@ func (i *Item) Mod(key ModKey, mod *Mod) *Item {
 	if i.mods == nil {
 		i.mods = make(map[ModKey]*Mod)
 	}
-	i.mods[key] = mod
+	i.mods[key.canonical()] = mod
 	return i
 }
 
@@ -131,7 +146,16 @@ func (i *Item) QuicklookURL(u string) *Item {
 	return i
 }
 
+// Action adds action for Universal Actions
+func (i *Item) Action(a *Action) *Item {
+	i.action = a
+	return i
+}
+
 func (i *Item) MarshalJSON() ([]byte, error) {
+	if err := i.mods.Validate(); err != nil {
+		return nil, err
+	}
 	out := i.internal()
 	return json.Marshal(out)
 }
@@ -144,7 +168,7 @@ func (i *Item) UnmarshalJSON(data []byte) error {
 	}
 
 	*i = *in.external()
-	return nil
+	return i.mods.Validate()
 }
 
 type iItems []*iItem
@@ -154,15 +178,16 @@ type iItem struct {
 	UID          string    `json:"uid,omitempty"`
 	Title        string    `json:"title"`
 	Subtitle     string    `json:"subtitle,omitempty"`
-	Arg          string    `json:"arg,omitempty"`
+	Arg          iStrings  `json:"arg,omitempty"`
 	Icon         *iIcon    `json:"icon,omitempty"`
 	Autocomplete string    `json:"autocomplete,omitempty"`
-	Type         string    `json:"type,omitempty"`
+	Type         ItemType  `json:"type,omitempty"`
 	Valid        *bool     `json:"valid,omitempty"`
 	Match        string    `json:"match,omitempty"`
 	Mods         iMods     `json:"mods,omitempty"`
 	Text         *iText    `json:"text,omitempty"`
 	QuicklookURL string    `json:"quicklookurl,omitempty"`
+	Action       *iAction  `json:"action,omitempty"`
 }
 
 func (i Items) internal() iItems {
@@ -196,6 +221,7 @@ func (i *Item) internal() *iItem {
 		Mods:         i.mods.internal(),
 		Text:         i.text.internal(),
 		QuicklookURL: i.quicklookURL,
+		Action:       i.action.internal(),
 	}
 }
 
@@ -214,5 +240,39 @@ func (i *iItem) external() *Item {
 		mods:         i.Mods.external(),
 		text:         i.Text.external(),
 		quicklookURL: i.QuicklookURL,
+		action:       i.Action.external(),
 	}
 }
+
+// newArgs returns nil if a single empty arg is passed so that arg is omitted
+func newArgs(args ...string) []string {
+	if len(args) == 0 || (len(args) == 1 && args[0] == "") {
+		return nil
+	}
+	return append([]string{}, args...)
+}
+
+// iStrings is a string or an array of strings in JSON
+type iStrings []string
+
+func (s iStrings) MarshalJSON() ([]byte, error) {
+	if len(s) == 1 {
+		return json.Marshal(s[0])
+	}
+	return json.Marshal([]string(s))
+}
+
+func (s *iStrings) UnmarshalJSON(data []byte) error {
+	var v string
+	if err := json.Unmarshal(data, &v); err == nil {
+		*s = iStrings{v}
+		return nil
+	}
+
+	var vs []string
+	if err := json.Unmarshal(data, &vs); err != nil {
+		return err
+	}
+	*s = vs
+	return nil
+}
@@ -0,0 +1,111 @@
+package alfred
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Action element defines the Universal Action items used when actioning the result
+type Action struct {
+	text []string
+	url  []string
+	file []string
+	auto []string
+}
+
+// NewAction generates new action
+func NewAction() *Action {
+	return new(Action)
+}
+
+// Text adds text values for Universal Actions
+func (a *Action) Text(s ...string) *Action {
+	a.text = append(a.text, s...)
+	return a
+}
+
+// URL adds url values for Universal Actions
+func (a *Action) URL(s ...string) *Action {
+	a.url = append(a.url, s...)
+	return a
+}
+
+// File adds file paths for Universal Actions
+func (a *Action) File(s ...string) *Action {
+	a.file = append(a.file, s...)
+	return a
+}
+
+// Auto adds values which Alfred treats as the type automatically
+func (a *Action) Auto(s ...string) *Action {
+	a.auto = append(a.auto, s...)
+	return a
+}
+
+func (a *Action) MarshalJSON() ([]byte, error) {
+	out := a.internal()
+	return json.Marshal(out)
+}
+
+func (a *Action) UnmarshalJSON(data []byte) error {
+	in := &iAction{}
+	err := json.Unmarshal(data, in)
+	if err != nil {
+		return err
+	}
+
+	*a = *in.external()
+	return nil
+}
+
+type iAction struct {
+	Text iStrings `json:"text,omitempty"`
+	URL  iStrings `json:"url,omitempty"`
+	File iStrings `json:"file,omitempty"`
+	Auto iStrings `json:"auto,omitempty"`
+}
+
+// iActionObject has no json methods to avoid recursive calls
+type iActionObject iAction
+
+// MarshalJSON outputs a string or an array if only auto exists, otherwise an object.
+// Alfred treats the string and array forms as auto
+func (a *iAction) MarshalJSON() ([]byte, error) {
+	if len(a.Text) == 0 && len(a.URL) == 0 && len(a.File) == 0 && len(a.Auto) > 0 {
+		return json.Marshal(a.Auto)
+	}
+	return json.Marshal((*iActionObject)(a))
+}
+
+// UnmarshalJSON accepts a string and an array of strings as auto, and an object
+func (a *iAction) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return json.Unmarshal(data, (*iActionObject)(a))
+	}
+	return json.Unmarshal(data, &a.Auto)
+}
+
+// internal returns nil for an empty action so that the action is omitted
+func (a *Action) internal() *iAction {
+	if a == nil || len(a.text)+len(a.url)+len(a.file)+len(a.auto) == 0 {
+		return nil
+	}
+	return &iAction{
+		Text: a.text,
+		URL:  a.url,
+		File: a.file,
+		Auto: a.auto,
+	}
+}
+
+func (a *iAction) external() *Action {
+	if a == nil {
+		return nil
+	}
+	return &Action{
+		text: a.Text,
+		url:  a.URL,
+		file: a.File,
+		auto: a.Auto,
+	}
+}
@@ -0,0 +1,111 @@
+package alfred
+
+import (
+	"os"
+	"testing"
+)
+
+func TestNewAction(t *testing.T) {
+	tests := []struct {
+		name     string
+		action   *Action
+		want     *Action
+		wantJSON string
+	}{
+		{
+			name:   "single auto",
+			action: NewAction().Auto("auto"),
+			want: &Action{
+				auto: []string{"auto"},
+			},
+			wantJSON: `"auto"`,
+		},
+		{
+			name:   "array of auto",
+			action: NewAction().Auto("auto1", "auto2"),
+			want: &Action{
+				auto: []string{"auto1", "auto2"},
+			},
+			wantJSON: `["auto1","auto2"]`,
+		},
+		{
+			name:   "text only",
+			action: NewAction().Text("text"),
+			want: &Action{
+				text: []string{"text"},
+			},
+			wantJSON: `{"text":"text"}`,
+		},
+		{
+			name:   "object",
+			action: NewAction().Text("text1", "text2").URL("https://www.alfredapp.com").File("~/Desktop").Auto("~/Pictures"),
+			want: &Action{
+				text: []string{"text1", "text2"},
+				url:  []string{"https://www.alfredapp.com"},
+				file: []string{"~/Desktop"},
+				auto: []string{"~/Pictures"},
+			},
+			wantJSON: `{"text":["text1","text2"],"url":"https://www.alfredapp.com","file":"~/Desktop","auto":"~/Pictures"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.action
+			if diff := Diff(tt.want, got); diff != "" {
+				t.Errorf("-want +got\n %s", diff)
+			}
+
+			// marshal/unmarshal test
+			b, err := got.MarshalJSON()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tt.wantJSON {
+				t.Errorf("want: %s\ngot: %s", tt.wantJSON, b)
+			}
+
+			got = NewAction()
+			if err := got.UnmarshalJSON(b); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := Diff(tt.want, got); diff != "" {
+				t.Errorf("-want +got\n %s", diff)
+			}
+		})
+	}
+}
+
+func TestItemAction(t *testing.T) {
+	t.Run("marshal and unmarshal actions", func(t *testing.T) {
+		want, err := os.ReadFile(testFilePath("test_scriptfilter_action.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sf := NewScriptFilter()
+		sf.Items(
+			NewItem().Title("string").Action(NewAction().Auto("auto")),
+			NewItem().Title("array").Action(NewAction().Auto("auto1", "auto2")),
+			NewItem().Title("text").Action(NewAction().Text("text")),
+			NewItem().Title("object").Action(
+				NewAction().Text("text1", "text2").URL("https://www.alfredapp.com").File("~/Desktop").Auto("~/Pictures"),
+			),
+		)
+
+		got := sf.Bytes()
+		if diff := DiffOutput(want, got); diff != "" {
+			t.Errorf("-want +got\n%+v", diff)
+		}
+	})
+
+	t.Run("empty action is omitted", func(t *testing.T) {
+		got, err := NewItem().Title("empty").Action(NewAction()).MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := `{"title":"empty"}`; string(got) != want {
+			t.Errorf("want %s got %s", want, got)
+		}
+	})
+}
@@ -0,0 +1,41 @@
+package alfred
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ItemType is a type of the item
+type ItemType string
+
+const (
+	// ItemTypeDefault is the default type
+	ItemTypeDefault ItemType = "default"
+	// ItemTypeFile lets Alfred treat the result as a file.
+	// Alfred checks if the file exists on the local filesystem
+	ItemTypeFile ItemType = "file"
+	// ItemTypeFileSkipCheck lets Alfred treat the result as a file without checking the existence
+	ItemTypeFileSkipCheck ItemType = "file:skipcheck"
+)
+
+// FileItem generates new item treated as a file.
+// The title, arg, quicklookurl and the icon of the file are derived from path
+func FileItem(path string) *Item {
+	return NewItem().
+		Title(filepath.Base(path)).
+		Subtitle(path).
+		Arg(path).
+		QuicklookURL(path).
+		Icon(NewIcon().Type(IconTypeFileIcon).Path(path)).
+		Type(ItemTypeFile)
+}
+
+// ExistingFileItem generates new item treated as a file after checking the file exists.
+// Alfred skips the existence check since it has already been done
+func ExistingFileItem(path string) (*Item, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("file item %s: %w", path, err)
+	}
+	return FileItem(path).Type(ItemTypeFileSkipCheck), nil
+}
@@ -0,0 +1,74 @@
+package alfred
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileItem(t *testing.T) {
+	existing := testFilePath("test_scriptfilter_items01.json")
+	missing := testFilePath("not-found.json")
+	tests := []struct {
+		name    string
+		path    string
+		check   bool
+		want    *Item
+		wantErr error
+	}{
+		{
+			name: "file item without check",
+			path: missing,
+			want: &Item{
+				title:        "not-found.json",
+				subtitle:     missing,
+				arg:          []string{missing},
+				quicklookURL: missing,
+				icon:         &Icon{typ: "fileicon", path: missing},
+				typ:          ItemTypeFile,
+			},
+		},
+		{
+			name:  "existing file item",
+			path:  existing,
+			check: true,
+			want: &Item{
+				title:        filepath.Base(existing),
+				subtitle:     existing,
+				arg:          []string{existing},
+				quicklookURL: existing,
+				icon:         &Icon{typ: "fileicon", path: existing},
+				typ:          ItemTypeFileSkipCheck,
+			},
+		},
+		{
+			name:    "missing file item",
+			path:    missing,
+			check:   true,
+			wantErr: os.ErrNotExist,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *Item
+			var err error
+			if tt.check {
+				got, err = ExistingFileItem(tt.path)
+			} else {
+				got = FileItem(tt.path)
+			}
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("want: %v, got: %v", tt.wantErr, err)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if diff := Diff(tt.want, got); diff != "" {
+				t.Errorf("-want +got\n%+v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package alfred
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+const (
+	tagName      = "alfred"
+	tagVarPrefix = "var:"
+	tagModPrefix = "mod:"
+)
+
+// ItemsFromOptions configures ItemsFrom
+type ItemsFromOptions struct {
+	// Templates are text/template expressions keyed by tag values such as "subtitle" or "var:NAME".
+	// Each template is executed with the element and the result overrides the tagged field
+	Templates map[string]string
+}
+
+// fieldMapping is a struct field mapped to an item property
+type fieldMapping struct {
+	index  []int
+	target string
+}
+
+// cache of field mappings per struct type
+var fieldMappings sync.Map
+
+// ItemsFrom converts a slice of structs into Items according to `alfred` struct tags.
+// Supported tags are title, subtitle, arg, uid, autocomplete, match, quicklookurl,
+// var:NAME for a variable and mod:KEY.arg or mod:KEY.subtitle for a mod e.g. `alfred:"mod:cmd.arg"`.
+// A []string field tagged as arg is passed out as multiple arguments
+func ItemsFrom(slice interface{}, opts *ItemsFromOptions) (Items, error) {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("ItemsFrom requires a slice but got %T", slice)
+	}
+
+	elemType := rv.Type().Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("ItemsFrom requires a slice of structs but got %T", slice)
+	}
+
+	mappings, err := getFieldMappings(elemType)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := parseItemTemplates(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make(Items, 0, rv.Len())
+	for idx := 0; idx < rv.Len(); idx++ {
+		elem := rv.Index(idx)
+		if elem.Kind() == reflect.Ptr {
+			if elem.IsNil() {
+				continue
+			}
+			elem = elem.Elem()
+		}
+
+		item := NewItem()
+		for _, m := range mappings {
+			// fields promoted through a nil embedded pointer are skipped
+			field, err := elem.FieldByIndexErr(m.index)
+			if err != nil {
+				continue
+			}
+			if err := setItemTarget(item, m.target, fieldValues(field)); err != nil {
+				return nil, err
+			}
+		}
+		for target, tmpl := range templates {
+			buf := new(strings.Builder)
+			if err := tmpl.Execute(buf, elem.Interface()); err != nil {
+				return nil, fmt.Errorf("failed to execute template of %s: %w", target, err)
+			}
+			if err := setItemTarget(item, target, []string{buf.String()}); err != nil {
+				return nil, err
+			}
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func getFieldMappings(t reflect.Type) ([]fieldMapping, error) {
+	if v, ok := fieldMappings.Load(t); ok {
+		return v.([]fieldMapping), nil
+	}
+
+	var mappings []fieldMapping
+	for _, f := range reflect.VisibleFields(t) {
+		target, ok := f.Tag.Lookup(tagName)
+		if !ok || target == "" || target == "-" || !f.IsExported() {
+			continue
+		}
+		if err := validateItemTarget(target); err != nil {
+			return nil, fmt.Errorf("field %s of %s: %w", f.Name, t, err)
+		}
+		mappings = append(mappings, fieldMapping{index: f.Index, target: target})
+	}
+
+	fieldMappings.Store(t, mappings)
+	return mappings, nil
+}
+
+func parseItemTemplates(opts *ItemsFromOptions) (map[string]*template.Template, error) {
+	templates := make(map[string]*template.Template)
+	if opts == nil {
+		return templates, nil
+	}
+
+	for target, text := range opts.Templates {
+		if err := validateItemTarget(target); err != nil {
+			return nil, err
+		}
+		tmpl, err := template.New(target).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template of %s: %w", target, err)
+		}
+		templates[target] = tmpl
+	}
+	return templates, nil
+}
+
+// fieldValues returns string representations of the field
+func fieldValues(v reflect.Value) []string {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String {
+		values := make([]string, v.Len())
+		for idx := range values {
+			values[idx] = v.Index(idx).String()
+		}
+		return values
+	}
+	return []string{fmt.Sprint(v.Interface())}
+}
+
+func validateItemTarget(target string) error {
+	return setItemTarget(nil, target, nil)
+}
+
+// setItemTarget sets values into the item property presented by target.
+// If item is nil, it only validates the target
+func setItemTarget(item *Item, target string, values []string) error {
+	value := strings.Join(values, " ")
+	switch {
+	case strings.HasPrefix(target, tagVarPrefix):
+		name := strings.TrimPrefix(target, tagVarPrefix)
+		if name == "" {
+			return fmt.Errorf("variable name is empty in %q", target)
+		}
+		if item != nil {
+			item.Variable(name, value)
+		}
+		return nil
+	case strings.HasPrefix(target, tagModPrefix):
+		return setModTarget(item, target, values)
+	}
+
+	var set func(*Item)
+	switch target {
+	case "title":
+		set = func(i *Item) { i.Title(value) }
+	case "subtitle":
+		set = func(i *Item) { i.Subtitle(value) }
+	case "arg":
+		set = func(i *Item) { i.Args(values...) }
+	case "uid":
+		set = func(i *Item) { i.UID(value) }
+	case "autocomplete":
+		set = func(i *Item) { i.Autocomplete(value) }
+	case "match":
+		set = func(i *Item) { i.Match(value) }
+	case "quicklookurl":
+		set = func(i *Item) { i.QuicklookURL(value) }
+	default:
+		return fmt.Errorf("unknown alfred tag %q", target)
+	}
+
+	if item != nil {
+		set(item)
+	}
+	return nil
+}
+
+// setModTarget sets values into the mod presented by a target such as mod:cmd.arg
+func setModTarget(item *Item, target string, values []string) error {
+	keyAndProp := strings.TrimPrefix(target, tagModPrefix)
+	pos := strings.LastIndex(keyAndProp, ".")
+	if pos < 0 {
+		return fmt.Errorf("mod property is missing in %q", target)
+	}
+
+	key, err := ParseModKey(keyAndProp[:pos])
+	if err != nil {
+		return fmt.Errorf("invalid mod key in %q: %w", target, err)
+	}
+
+	prop := keyAndProp[pos+1:]
+	if prop != "arg" && prop != "subtitle" {
+		return fmt.Errorf("unknown mod property %q in %q", prop, target)
+	}
+	// do not create an empty mod
+	if item == nil || strings.Join(values, "") == "" {
+		return nil
+	}
+
+	mod, ok := item.mods[key]
+	if !ok || mod == nil {
+		mod = NewMod()
+		item.Mod(key, mod)
+	}
+	if prop == "arg" {
+		mod.Args(values...)
+	} else {
+		mod.Subtitle(strings.Join(values, " "))
+	}
+	return nil
+}
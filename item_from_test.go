@@ -0,0 +1,115 @@
+package alfred
+
+import (
+	"testing"
+)
+
+type testRepository struct {
+	Name     string   `alfred:"title"`
+	Owner    string   `alfred:"var:owner"`
+	URL      string   `alfred:"arg"`
+	ID       int      `alfred:"uid"`
+	Topics   []string `alfred:"mod:cmd.arg"`
+	Homepage *string  `alfred:"mod:alt.subtitle"`
+	internal string
+}
+
+type testOwner struct {
+	Login string `alfred:"subtitle"`
+}
+
+type testEmbeddedRepository struct {
+	*testOwner
+	ID int `alfred:"uid"`
+}
+
+func TestItemsFrom(t *testing.T) {
+	homepage := "https://example.com"
+	tests := []struct {
+		name    string
+		input   interface{}
+		opts    *ItemsFromOptions
+		want    Items
+		wantErr bool
+	}{
+		{
+			name: "map struct tags",
+			input: []testRepository{
+				{Name: "go-alfred", Owner: "konoui", URL: "https://github.com/konoui/go-alfred", ID: 1, Topics: []string{"go", "alfred"}, Homepage: &homepage},
+			},
+			want: Items{
+				NewItem().
+					Title("go-alfred").
+					Variable("owner", "konoui").
+					Arg("https://github.com/konoui/go-alfred").
+					UID("1").
+					Mod(ModCmd, NewMod().Args("go", "alfred")).
+					Mod(ModAlt, NewMod().Subtitle(homepage)),
+			},
+		},
+		{
+			name: "slice of pointers with templates",
+			input: []*testRepository{
+				{Name: "go-alfred", Owner: "konoui", ID: 1},
+				nil,
+			},
+			opts: &ItemsFromOptions{
+				Templates: map[string]string{
+					"subtitle": "{{ .Owner }}/{{ .Name }}",
+				},
+			},
+			want: Items{
+				NewItem().
+					Title("go-alfred").
+					Subtitle("konoui/go-alfred").
+					Variable("owner", "konoui").
+					UID("1"),
+			},
+		},
+		{
+			name:    "not a slice",
+			input:   testRepository{},
+			wantErr: true,
+		},
+		{
+			name: "unknown tag",
+			input: []struct {
+				Name string `alfred:"name"`
+			}{},
+			wantErr: true,
+		},
+		{
+			name: "nil embedded pointer",
+			input: []testEmbeddedRepository{
+				{ID: 1},
+				{testOwner: &testOwner{Login: "konoui"}, ID: 2},
+			},
+			want: Items{
+				NewItem().UID("1"),
+				NewItem().Subtitle("konoui").UID("2"),
+			},
+		},
+		{
+			name:  "invalid template",
+			input: []testRepository{},
+			opts: &ItemsFromOptions{
+				Templates: map[string]string{"title": "{{ .Name "},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ItemsFrom(tt.input, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ItemsFrom() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := Diff(tt.want, got); diff != "" {
+				t.Errorf("-want +got\n%+v", diff)
+			}
+		})
+	}
+}
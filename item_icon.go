@@ -1,6 +1,76 @@
 package alfred
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	// IconTypeFileIcon uses the icon of the file at the path
+	IconTypeFileIcon = "fileicon"
+	// IconTypeFileType uses the icon for the UTI at the path e.g. public.folder
+	IconTypeFileType = "filetype"
+)
+
+var utiPattern = regexp.MustCompile(`^[A-Za-z0-9-]+(\.[A-Za-z0-9-]+)+$`)
+
+// IconImage generates new icon displaying the image at the path.
+// A relative path is resolved from the workflow directory
+func IconImage(path string) (*Icon, error) {
+	if err := checkIconPath(path); err != nil {
+		return nil, err
+	}
+	return NewIcon().Path(path), nil
+}
+
+// IconFileIcon generates new icon of the file at the path.
+// A relative path is resolved from the workflow directory
+func IconFileIcon(path string) (*Icon, error) {
+	if err := checkIconPath(path); err != nil {
+		return nil, err
+	}
+	return NewIcon().Type(IconTypeFileIcon).Path(path), nil
+}
+
+// IconFileType generates new icon of the UTI such as public.folder
+func IconFileType(uti string) (*Icon, error) {
+	if !utiPattern.MatchString(uti) {
+		return nil, fmt.Errorf("invalid uniform type identifier %q", uti)
+	}
+	return NewIcon().Type(IconTypeFileType).Path(uti), nil
+}
+
+// checkIconPath returns an error if the path does not exist.
+// Alfred resolves relative paths from the workflow directory
+func checkIconPath(path string) error {
+	if path == "" {
+		return fmt.Errorf("icon path is empty")
+	}
+
+	p := path
+	if strings.HasPrefix(p, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		p = filepath.Join(home, p[2:])
+	} else if !filepath.IsAbs(p) {
+		dir, err := GetWorkflowDir()
+		if err != nil {
+			return fmt.Errorf("cannot resolve icon path %s: %w", path, err)
+		}
+		p = filepath.Join(dir, p)
+	}
+
+	if _, err := os.Stat(p); err != nil {
+		return fmt.Errorf("icon path %s: %w", path, err)
+	}
+	return nil
+}
 
 // Icon displayed in the result row
 type Icon struct {
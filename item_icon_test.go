@@ -1,7 +1,11 @@
 package alfred
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/konoui/go-alfred/env"
 )
 
 func TestNewIcon(t *testing.T) {
@@ -42,3 +46,70 @@ func TestNewIcon(t *testing.T) {
 		})
 	}
 }
+
+func TestIconConstructors(t *testing.T) {
+	base := t.TempDir()
+	uid := "user.workflow.test"
+	workflowDir := filepath.Join(base, "workflows", uid)
+	if err := os.MkdirAll(workflowDir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(workflowDir, "icon.png"), []byte{}, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(env.KeyWorkflowPreferences, base)
+	t.Setenv(env.KeyWorkflowUID, uid)
+
+	absPath := filepath.Join(workflowDir, "icon.png")
+	tests := []struct {
+		name    string
+		fn      func() (*Icon, error)
+		want    *Icon
+		wantErr bool
+	}{
+		{
+			name: "image with relative path",
+			fn:   func() (*Icon, error) { return IconImage("icon.png") },
+			want: &Icon{path: "icon.png"},
+		},
+		{
+			name:    "image does not exist",
+			fn:      func() (*Icon, error) { return IconImage("not-found.png") },
+			wantErr: true,
+		},
+		{
+			name: "fileicon with absolute path",
+			fn:   func() (*Icon, error) { return IconFileIcon(absPath) },
+			want: &Icon{typ: IconTypeFileIcon, path: absPath},
+		},
+		{
+			name:    "fileicon with empty path",
+			fn:      func() (*Icon, error) { return IconFileIcon("") },
+			wantErr: true,
+		},
+		{
+			name: "filetype",
+			fn:   func() (*Icon, error) { return IconFileType("public.folder") },
+			want: &Icon{typ: IconTypeFileType, path: "public.folder"},
+		},
+		{
+			name:    "invalid filetype",
+			fn:      func() (*Icon, error) { return IconFileType("/Applications") },
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := Diff(tt.want, got); diff != "" {
+				t.Errorf("-want +got\n %s", diff)
+			}
+		})
+	}
+}
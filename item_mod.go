@@ -1,6 +1,10 @@
 package alfred
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
 
 // ModKey is a mod key pressed by the user to run an alternate
 type ModKey string
@@ -15,13 +19,104 @@ const (
 	ModFn    ModKey = "fn"    // Alternate action for fn↩
 )
 
+const modKeySeparator = "+"
+
+// modKeyOrder is the canonical order of keys in a combination
+var modKeyOrder = []ModKey{ModCmd, ModAlt, ModCtrl, ModShift, ModFn}
+
+// With combines the key with other keys e.g. ModCmd.With(ModShift) returns "cmd+shift".
+// The combination is sorted in canonical order and duplicated keys are removed.
+func (m ModKey) With(keys ...ModKey) ModKey {
+	combined := append([]ModKey{m}, keys...)
+	return joinModKeys(splitModKeys(combined...))
+}
+
+// Validate returns an error if the key contains unknown keys
+func (m ModKey) Validate() error {
+	if m == "" {
+		return fmt.Errorf("mod key is empty")
+	}
+	for _, k := range strings.Split(string(m), modKeySeparator) {
+		if !isSingleModKey(ModKey(k)) {
+			return fmt.Errorf("unknown mod key %q in %q", k, m)
+		}
+	}
+	return nil
+}
+
+// ParseModKey parses a key such as "shift+cmd" and returns the canonical key "cmd+shift"
+func ParseModKey(s string) (ModKey, error) {
+	key := ModKey(s).With()
+	if err := key.Validate(); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+func (m ModKey) canonical() ModKey {
+	return m.With()
+}
+
+func isSingleModKey(k ModKey) bool {
+	for _, key := range modKeyOrder {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// splitModKeys returns known keys in canonical order followed by unknown keys
+func splitModKeys(keys ...ModKey) []ModKey {
+	seen := make(map[ModKey]bool)
+	var unknown []ModKey
+	for _, key := range keys {
+		for _, s := range strings.Split(string(key), modKeySeparator) {
+			k := ModKey(strings.ToLower(strings.TrimSpace(s)))
+			if k == "" || seen[k] {
+				continue
+			}
+			seen[k] = true
+			if !isSingleModKey(k) {
+				unknown = append(unknown, k)
+			}
+		}
+	}
+
+	ret := make([]ModKey, 0, len(seen))
+	for _, k := range modKeyOrder {
+		if seen[k] {
+			ret = append(ret, k)
+		}
+	}
+	return append(ret, unknown...)
+}
+
+func joinModKeys(keys []ModKey) ModKey {
+	s := make([]string, len(keys))
+	for idx, k := range keys {
+		s[idx] = string(k)
+	}
+	return ModKey(strings.Join(s, modKeySeparator))
+}
+
 type Mods map[ModKey]*Mod
 
+// Validate returns an error if the mods contain unknown keys
+func (m Mods) Validate() error {
+	for k := range m {
+		if err := k.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Mod element gives you control over how the modifier keys react
 type Mod struct {
 	variables Variables
 	valid     *bool
-	arg       string
+	arg       []string
 	subtitle  string
 	icon      *Icon
 }
@@ -56,7 +151,13 @@ func (m *Mod) Valid(b bool) *Mod {
 
 // Arg adds mod argument
 func (m *Mod) Arg(s string) *Mod {
-	m.arg = s
+	m.arg = newArgs(s)
+	return m
+}
+
+// Args adds mod multiple arguments passed out as an array
+func (m *Mod) Args(args ...string) *Mod {
+	m.arg = newArgs(args...)
 	return m
 }
 
@@ -93,7 +194,7 @@ type iMods map[ModKey]*iMod
 type iMod struct {
 	Variables Variables `json:"variables,omitempty"`
 	Valid     *bool     `json:"valid,omitempty"`
-	Arg       string    `json:"arg,omitempty"`
+	Arg       iStrings  `json:"arg,omitempty"`
 	Subtitle  string    `json:"subtitle,omitempty"`
 	Icon      *iIcon    `json:"icon,omitempty"`
 }
@@ -101,7 +202,7 @@ type iMod struct {
 func (m Mods) internal() iMods {
 	mods := make(map[ModKey]*iMod)
 	for k, v := range m {
-		mods[k] = v.internal()
+		mods[k.canonical()] = v.internal()
 	}
 	return mods
 }
@@ -109,7 +210,7 @@ func (m Mods) internal() iMods {
 func (m iMods) external() Mods {
 	mods := make(map[ModKey]*Mod)
 	for k, v := range m {
-		mods[k] = v.external()
+		mods[k.canonical()] = v.external()
 	}
 	return mods
 }
@@ -18,7 +18,7 @@ func TestNewMod(t *testing.T) {
 					"key3": "3",
 				},
 				valid:    boolP(true),
-				arg:      "arg",
+				arg:      []string{"arg"},
 				subtitle: "subtitle",
 				icon: &Icon{
 					typ:  "typ",
@@ -56,3 +56,97 @@ func TestNewMod(t *testing.T) {
 		})
 	}
 }
+
+func TestModKey_With(t *testing.T) {
+	tests := []struct {
+		name    string
+		got     ModKey
+		want    ModKey
+		wantErr bool
+	}{
+		{
+			name: "single key",
+			got:  ModCmd.With(),
+			want: "cmd",
+		},
+		{
+			name: "canonical order",
+			got:  ModShift.With(ModCmd),
+			want: "cmd+shift",
+		},
+		{
+			name: "remove duplicated keys",
+			got:  ModAlt.With(ModOpt, ModCmd, ModCtrl, ModCmd),
+			want: "cmd+alt+ctrl",
+		},
+		{
+			name: "combined keys",
+			got:  ModFn.With(ModKey("shift+cmd")),
+			want: "cmd+shift+fn",
+		},
+		{
+			name:    "unknown key",
+			got:     ModCmd.With(ModKey("command")),
+			want:    "cmd+command",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("want: %s, got: %s", tt.want, tt.got)
+			}
+			if err := tt.got.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseModKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    ModKey
+		wantErr bool
+	}{
+		{name: "single", input: "cmd", want: ModCmd},
+		{name: "combination", input: "shift+ctrl+alt", want: "alt+ctrl+shift"},
+		{name: "typo", input: "cmd+shfit", wantErr: true},
+		{name: "empty", input: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseModKey(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseModKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("want: %s, got: %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDiffMods(t *testing.T) {
+	t.Run("equivalent orderings are equal", func(t *testing.T) {
+		want := Mods{"cmd+shift": NewMod().Arg("arg")}
+		got := Mods{"shift+cmd": NewMod().Arg("arg")}
+		if diff := Diff(want, got); diff != "" {
+			t.Errorf("-want +got\n %s", diff)
+		}
+	})
+}
+
+func TestItemModsValidation(t *testing.T) {
+	t.Run("reject unknown mod key", func(t *testing.T) {
+		item := NewItem().Title("title").Mod(ModKey("cmd+shfit"), NewMod())
+		if _, err := item.MarshalJSON(); err == nil {
+			t.Error("want error but got nil")
+		}
+
+		if err := NewItem().UnmarshalJSON([]byte(`{"title":"title","mods":{"super":{}}}`)); err == nil {
+			t.Error("want error but got nil")
+		}
+	})
+}
@@ -21,7 +21,7 @@ func TestItemAPI(t *testing.T) {
 			item := NewItem().
 				Title(input.title).
 				Subtitle(input.subtitle).
-				Arg(input.arg).
+				Args(input.arg...).
 				Autocomplete(input.autocomplete).
 				Match(input.match).
 				QuicklookURL(input.quicklookURL).
@@ -50,7 +50,7 @@ func TestItemAPI(t *testing.T) {
 
 			for k, v := range input.mods {
 				inputMod := input.mods[k]
-				mod := NewMod().Arg(inputMod.arg).Subtitle(inputMod.subtitle)
+				mod := NewMod().Args(inputMod.arg...).Subtitle(inputMod.subtitle)
 				for k, v := range inputMod.variables {
 					mod.Variable(k, v)
 				}
@@ -83,3 +83,51 @@ func Test_Variables(t *testing.T) {
 		}
 	})
 }
+
+func TestItemArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		item     *Item
+		wantJSON string
+	}{
+		{
+			name:     "empty arg is omitted",
+			item:     NewItem().Title("title").Arg(""),
+			wantJSON: `{"title":"title"}`,
+		},
+		{
+			name:     "single arg is string",
+			item:     NewItem().Title("title").Args("arg1"),
+			wantJSON: `{"title":"title","arg":"arg1"}`,
+		},
+		{
+			name:     "multiple args are array",
+			item:     NewItem().Title("title").Args("arg1", "arg2"),
+			wantJSON: `{"title":"title","arg":["arg1","arg2"]}`,
+		},
+		{
+			name:     "mod args are array",
+			item:     NewItem().Title("title").Mod(ModCmd, NewMod().Args("arg1", "arg2")),
+			wantJSON: `{"title":"title","mods":{"cmd":{"arg":["arg1","arg2"]}}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := tt.item.MarshalJSON()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tt.wantJSON {
+				t.Errorf("want: %s\ngot: %s", tt.wantJSON, b)
+			}
+
+			got := NewItem()
+			if err := got.UnmarshalJSON(b); err != nil {
+				t.Fatal(err)
+			}
+			if diff := Diff(tt.item, got); diff != "" {
+				t.Errorf("-want +got\n%+v", diff)
+			}
+		})
+	}
+}
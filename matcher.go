@@ -0,0 +1,156 @@
+package alfred
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/sahilm/fuzzy"
+)
+
+// Matcher decides whether a string matches a query
+type Matcher interface {
+	// Match returns a score and true if s matches the query.
+	// A higher score means a better match
+	Match(query, s string) (score int, ok bool)
+}
+
+// MatcherFunc is an adapter to use a function as Matcher
+type MatcherFunc func(query, s string) (int, bool)
+
+// Match calls f(query, s)
+func (f MatcherFunc) Match(query, s string) (int, bool) {
+	return f(query, s)
+}
+
+// FuzzyMatcher matches characters of the query in order with github.com/sahilm/fuzzy
+func FuzzyMatcher() Matcher {
+	return MatcherFunc(func(query, s string) (int, bool) {
+		matches := fuzzy.Find(query, []string{s})
+		if len(matches) == 0 {
+			return 0, false
+		}
+		return matches[0].Score, true
+	})
+}
+
+// PrefixMatcher matches strings starting with the query case-insensitively.
+// Shorter strings get higher scores
+func PrefixMatcher() Matcher {
+	return MatcherFunc(func(query, s string) (int, bool) {
+		if !strings.HasPrefix(strings.ToLower(s), strings.ToLower(query)) {
+			return 0, false
+		}
+		return coverageScore(query, s), true
+	})
+}
+
+// SubstringMatcher matches strings containing the query case-insensitively.
+// Shorter strings and earlier positions get higher scores
+func SubstringMatcher() Matcher {
+	return MatcherFunc(func(query, s string) (int, bool) {
+		idx := strings.Index(strings.ToLower(s), strings.ToLower(query))
+		if idx < 0 {
+			return 0, false
+		}
+		return coverageScore(query, s) - idx, true
+	})
+}
+
+// WordInitialsMatcher matches initials of words like Alfred e.g. "gc" matches "Google Chrome".
+// A prefix of a word also matches e.g. "chr" matches "Google Chrome"
+func WordInitialsMatcher() Matcher {
+	return MatcherFunc(func(query, s string) (int, bool) {
+		q := strings.ToLower(strings.Join(strings.Fields(query), ""))
+		if q == "" {
+			return 0, false
+		}
+
+		words := splitWords(s)
+		initials := make([]rune, 0, len(words))
+		for _, w := range words {
+			initials = append(initials, []rune(strings.ToLower(w))[0])
+		}
+		if strings.HasPrefix(string(initials), q) {
+			// initials are prioritized over word prefixes
+			return 100 + len([]rune(q))*100/len(initials), true
+		}
+
+		for idx, w := range words {
+			if strings.HasPrefix(strings.ToLower(w), q) {
+				return coverageScore(q, w) - idx, true
+			}
+		}
+		return 0, false
+	})
+}
+
+// RegexpMatcher treats the query as a case-insensitive regular expression.
+// Invalid expressions match nothing
+func RegexpMatcher() Matcher {
+	m := &regexpMatcher{}
+	return MatcherFunc(m.match)
+}
+
+type regexpMatcher struct {
+	mu    sync.Mutex
+	query string
+	re    *regexp.Regexp
+}
+
+func (m *regexpMatcher) match(query, s string) (int, bool) {
+	m.mu.Lock()
+	// compile once per query since Match is called for each item
+	if m.re == nil || m.query != query {
+		m.query = query
+		m.re, _ = regexp.Compile("(?i)" + query)
+	}
+	re := m.re
+	m.mu.Unlock()
+
+	if re == nil {
+		return 0, false
+	}
+	loc := re.FindStringIndex(s)
+	if loc == nil {
+		return 0, false
+	}
+	return coverageScore(s[loc[0]:loc[1]], s) - loc[0], true
+}
+
+// coverageScore returns a percentage of the matched length
+func coverageScore(matched, s string) int {
+	l := len([]rune(s))
+	if l == 0 {
+		return 0
+	}
+	return len([]rune(matched)) * 100 / l
+}
+
+// splitWords splits s by spaces, punctuations and camel case boundaries
+func splitWords(s string) []string {
+	var words []string
+	var word []rune
+	var prev rune
+	for _, r := range s {
+		if unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r) {
+			if len(word) > 0 {
+				words = append(words, string(word))
+			}
+			word = word[:0]
+			prev = r
+			continue
+		}
+		if unicode.IsUpper(r) && unicode.IsLower(prev) && len(word) > 0 {
+			words = append(words, string(word))
+			word = word[:0]
+		}
+		word = append(word, r)
+		prev = r
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+	return words
+}
@@ -0,0 +1,78 @@
+package alfred
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatchers(t *testing.T) {
+	tests := []struct {
+		name    string
+		matcher Matcher
+		query   string
+		s       string
+		want    bool
+	}{
+		{name: "fuzzy", matcher: FuzzyMatcher(), query: "gle", s: "Google", want: true},
+		{name: "fuzzy not match", matcher: FuzzyMatcher(), query: "elg", s: "Google", want: false},
+		{name: "prefix", matcher: PrefixMatcher(), query: "goo", s: "Google Chrome", want: true},
+		{name: "prefix not match", matcher: PrefixMatcher(), query: "chrome", s: "Google Chrome", want: false},
+		{name: "substring", matcher: SubstringMatcher(), query: "CHROME", s: "Google Chrome", want: true},
+		{name: "substring not match", matcher: SubstringMatcher(), query: "firefox", s: "Google Chrome", want: false},
+		{name: "word initials", matcher: WordInitialsMatcher(), query: "gc", s: "Google Chrome", want: true},
+		{name: "word initials with camel case", matcher: WordInitialsMatcher(), query: "vsc", s: "VisualStudio Code", want: true},
+		{name: "word prefix", matcher: WordInitialsMatcher(), query: "chr", s: "Google Chrome", want: true},
+		{name: "word initials not match", matcher: WordInitialsMatcher(), query: "cg", s: "Google Chrome", want: false},
+		{name: "regexp", matcher: RegexpMatcher(), query: "^goo.+me$", s: "Google Chrome", want: true},
+		{name: "regexp not match", matcher: RegexpMatcher(), query: "^chrome", s: "Google Chrome", want: false},
+		{name: "invalid regexp", matcher: RegexpMatcher(), query: "(", s: "Google Chrome", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, got := tt.matcher.Match(tt.query, tt.s); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestItems_FilterWithMatcher(t *testing.T) {
+	items := Items{
+		NewItem().Title("Google Chrome Canary"),
+		NewItem().Title("Firefox"),
+		NewItem().Title("Google Chrome"),
+	}
+	tests := []struct {
+		name    string
+		matcher Matcher
+		query   string
+		want    []string
+	}{
+		{
+			name:    "prefix sorted by score",
+			matcher: PrefixMatcher(),
+			query:   "google",
+			want:    []string{"Google Chrome", "Google Chrome Canary"},
+		},
+		{
+			name:    "word initials prefers initials",
+			matcher: WordInitialsMatcher(),
+			query:   "gcc",
+			want:    []string{"Google Chrome Canary"},
+		},
+		{
+			name:    "custom matcher",
+			matcher: MatcherFunc(func(query, s string) (int, bool) { return 0, s == query }),
+			query:   "Firefox",
+			want:    []string{"Firefox"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := testWorkflow().Append(items...).Filter(tt.query, WithMatcher(tt.matcher)).items
+			if !reflect.DeepEqual(titles(got), tt.want) {
+				t.Errorf("want %v got %v", tt.want, titles(got))
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package alfred
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+)
+
+// OrderingPolicy controls how Alfred's knowledge affects the order of items.
+// Policies can be combined with bitwise OR
+type OrderingPolicy int
+
+const (
+	// OrderingPreSorted marks items as sorted so that Alfred does not reorder them by knowledge
+	OrderingPreSorted OrderingPolicy = 1 << iota
+	// OrderingFillUID fills missing uids derived from title and arg so that Alfred can learn them
+	OrderingFillUID
+	// OrderingWarnDuplicateUID logs a warning when uids are duplicated on output
+	OrderingWarnDuplicateUID
+)
+
+// WithOrderingPolicy configures the ordering policy of items
+func WithOrderingPolicy(p OrderingPolicy) Option {
+	return func(wf *Workflow) {
+		wf.customEnvs.ordering = p
+	}
+}
+
+// SkipKnowledge sets skipknowledge for ScriptFilter
+func (w *Workflow) SkipKnowledge(b bool) *Workflow {
+	w.ScriptFilter.SkipKnowledge(b)
+	return w
+}
+
+func (p OrderingPolicy) has(flag OrderingPolicy) bool {
+	return p&flag != 0
+}
+
+// logValidationErrors logs all validation errors in debug mode
+// and duplicated uids if OrderingWarnDuplicateUID is set
+func (w *Workflow) logValidationErrors() {
+	debug := IsDebugEnabled()
+	if !debug && !w.customEnvs.ordering.has(OrderingWarnDuplicateUID) {
+		return
+	}
+	for _, err := range w.Validate() {
+		if debug || err.Field == "uid" {
+			w.sLogger().Warnln("invalid script filter:", err)
+		}
+	}
+}
+
+// deriveUID returns a stable uid from title and arg
+func deriveUID(item *Item) string {
+	h := fnv.New64a()
+	h.Write([]byte(item.title))
+	h.Write([]byte{0})
+	h.Write([]byte(strings.Join(item.arg, "\x00")))
+	return fmt.Sprintf("%x", h.Sum64())
+}
@@ -0,0 +1,105 @@
+package alfred
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/konoui/go-alfred/env"
+)
+
+func TestWorkflow_OrderingPolicy(t *testing.T) {
+	// duplicated uids are warned without debug mode
+	t.Setenv(env.KeyWorkflowDebug, "false")
+	tests := []struct {
+		name              string
+		policy            OrderingPolicy
+		items             Items
+		wantSkipKnowledge bool
+		wantUIDs          []string
+		wantLog           string
+	}{
+		{
+			name:     "no policy",
+			items:    Items{NewItem().Title("title1")},
+			wantUIDs: []string{""},
+		},
+		{
+			name:              "pre-sorted",
+			policy:            OrderingPreSorted,
+			items:             Items{NewItem().Title("title1")},
+			wantSkipKnowledge: true,
+			wantUIDs:          []string{""},
+		},
+		{
+			name:   "fill uid",
+			policy: OrderingFillUID,
+			items: Items{
+				NewItem().Title("title1").Arg("arg1"),
+				NewItem().Title("title2").UID("uid2"),
+			},
+			wantUIDs: []string{deriveUID(NewItem().Title("title1").Arg("arg1")), "uid2"},
+		},
+		{
+			name:   "warn duplicated uid",
+			policy: OrderingFillUID | OrderingWarnDuplicateUID,
+			items: Items{
+				NewItem().Title("title1"),
+				NewItem().Title("title1"),
+			},
+			wantUIDs: []string{deriveUID(NewItem().Title("title1")), deriveUID(NewItem().Title("title1"))},
+			wantLog:  "is duplicated with items[0]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outBuf, logBuf := new(bytes.Buffer), new(bytes.Buffer)
+			w := testWorkflow(
+				WithOrderingPolicy(tt.policy),
+				WithOutWriter(outBuf),
+				WithLogWriter(logBuf),
+			)
+			w.Append(tt.items...).Output()
+
+			got := new(ScriptFilter)
+			if err := got.UnmarshalJSON(outBuf.Bytes()); err != nil {
+				t.Fatal(err)
+			}
+			if got.skipKnowledge != tt.wantSkipKnowledge {
+				t.Errorf("skipknowledge want %v got %v", tt.wantSkipKnowledge, got.skipKnowledge)
+			}
+			for idx, uid := range tt.wantUIDs {
+				if got.items[idx].uid != uid {
+					t.Errorf("items[%d].uid want %q got %q", idx, uid, got.items[idx].uid)
+				}
+			}
+			if !strings.Contains(logBuf.String(), tt.wantLog) {
+				t.Errorf("want log: %s\ngot: %s", tt.wantLog, logBuf.String())
+			}
+		})
+	}
+}
+
+func TestWorkflow_OrderingPolicyKeepsItems(t *testing.T) {
+	w := testWorkflow(WithOrderingPolicy(OrderingPreSorted | OrderingFillUID))
+	w.Append(NewItem().Title("title1"))
+
+	first := w.Bytes()
+	if w.items[0].uid != "" {
+		t.Errorf("uid of the item should not be modified: %q", w.items[0].uid)
+	}
+	if w.ScriptFilter.skipKnowledge {
+		t.Error("skipknowledge of the workflow should not be modified")
+	}
+	if diff := DiffOutput(first, w.Bytes()); diff != "" {
+		t.Errorf("output should be the same\n%s", diff)
+	}
+
+	got := new(ScriptFilter)
+	if err := got.UnmarshalJSON(first); err != nil {
+		t.Fatal(err)
+	}
+	if !got.skipKnowledge || got.items[0].uid != deriveUID(w.items[0]) {
+		t.Errorf("unexpected output %s", first)
+	}
+}
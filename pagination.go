@@ -0,0 +1,92 @@
+package alfred
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// pageTokenPrefix is appended to the query by the "more results" item
+const pageTokenPrefix = "#page:"
+
+// WithPagination enables pagination instead of truncating items by WithMaxResults.
+// When items exceed the max results, a "more results" item is appended after sections.
+// Sections are not paginated and output only on the first page.
+// Actioning the item autocompletes the query with a page token and the workflow serves the next page.
+func WithPagination() Option {
+	return func(wf *Workflow) {
+		wf.customEnvs.pagination = true
+	}
+}
+
+// Page returns the current page number starting from 1.
+// It is always 1 if pagination is disabled
+func (w *Workflow) Page() int {
+	if !w.customEnvs.pagination {
+		return 1
+	}
+	_, page := splitPageToken(w.args)
+	return page
+}
+
+// pagedItems returns items in the current page and the item for the next page if exists
+func (w *Workflow) pagedItems(items Items) (Items, *Item) {
+	// if maxResults equal 0, this means unlimited
+	limit := w.customEnvs.maxResults
+	if !w.customEnvs.pagination || limit <= 0 {
+		if limit > 0 && len(items) > limit {
+			return items[:limit], nil
+		}
+		return items, nil
+	}
+
+	start := (w.Page() - 1) * limit
+	if start >= len(items) {
+		return Items{}, nil
+	}
+	end := start + limit
+	if end >= len(items) {
+		return items[start:], nil
+	}
+
+	query := strings.Join(w.Args(), " ")
+	if query != "" {
+		query += " "
+	}
+	more := NewItem().
+		Title(fmt.Sprintf("%d more results…", len(items)-end)).
+		Subtitle("Select to show the next page").
+		Autocomplete(query + pageToken(w.Page()+1)).
+		Valid(false)
+	return items[start:end], more
+}
+
+func pageToken(page int) string {
+	return pageTokenPrefix + strconv.Itoa(page)
+}
+
+// splitPageToken removes a page token from the end of args and returns the page number
+func splitPageToken(args []string) ([]string, int) {
+	if len(args) == 0 {
+		return args, 1
+	}
+
+	last := args[len(args)-1]
+	pos := strings.LastIndex(last, pageTokenPrefix)
+	if pos < 0 || (pos > 0 && last[pos-1] != ' ') {
+		return args, 1
+	}
+	page, err := strconv.Atoi(last[pos+len(pageTokenPrefix):])
+	if err != nil || page < 1 {
+		return args, 1
+	}
+
+	ret := make([]string, len(args))
+	copy(ret, args)
+	rest := strings.TrimSpace(last[:pos])
+	if rest == "" {
+		return ret[:len(ret)-1], page
+	}
+	ret[len(ret)-1] = rest
+	return ret, page
+}
@@ -0,0 +1,129 @@
+package alfred
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_splitPageToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantArgs []string
+		wantPage int
+	}{
+		{name: "no args", args: []string{}, wantArgs: []string{}, wantPage: 1},
+		{name: "no token", args: []string{"query"}, wantArgs: []string{"query"}, wantPage: 1},
+		{name: "token in the query", args: []string{"query #page:2"}, wantArgs: []string{"query"}, wantPage: 2},
+		{name: "token as an arg", args: []string{"query", "#page:3"}, wantArgs: []string{"query"}, wantPage: 3},
+		{name: "token only", args: []string{"#page:2"}, wantArgs: []string{}, wantPage: 2},
+		{name: "invalid page", args: []string{"query #page:0"}, wantArgs: []string{"query #page:0"}, wantPage: 1},
+		{name: "not a token", args: []string{"query#page:2"}, wantArgs: []string{"query#page:2"}, wantPage: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotArgs, gotPage := splitPageToken(tt.args)
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Errorf("args want %v got %v", tt.wantArgs, gotArgs)
+			}
+			if gotPage != tt.wantPage {
+				t.Errorf("page want %d got %d", tt.wantPage, gotPage)
+			}
+		})
+	}
+}
+
+func TestWorkflow_Pagination(t *testing.T) {
+	items := Items{
+		NewItem().Title("title1"),
+		NewItem().Title("title2"),
+		NewItem().Title("title3"),
+	}
+	tests := []struct {
+		name   string
+		args   []string
+		system *Item
+		want   Items
+	}{
+		{
+			name: "first page",
+			args: []string{"query"},
+			want: Items{
+				items[0],
+				items[1],
+				NewItem().Title("1 more results…").Subtitle("Select to show the next page").
+					Autocomplete("query #page:2").Valid(false),
+			},
+		},
+		{
+			name:   "last page with system info",
+			args:   []string{"query #page:2"},
+			system: &systemItem,
+			want: Items{
+				&systemItem,
+				items[2],
+			},
+		},
+		{
+			name: "out of range page shows empty warning",
+			args: []string{"query #page:3"},
+			want: Items{
+				NewItem().Title(emptyItem.title).Subtitle(emptyItem.subtitle).Valid(false).Icon(IconAlertNote()),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := testWorkflow(WithMaxResults(2), WithPagination(), WithArguments(tt.args...))
+			w.SetEmptyWarning(emptyItem.title, emptyItem.subtitle)
+			w.SetSystemInfo(tt.system)
+			w.Append(items...)
+
+			if got := w.Args(); !reflect.DeepEqual(got, []string{"query"}) {
+				t.Errorf("args want [query] got %v", got)
+			}
+
+			sf := NewScriptFilter()
+			sf.Items(tt.want...)
+			if diff := DiffOutput(sf.Bytes(), w.Bytes()); diff != "" {
+				t.Errorf("-want +got\n%+v", diff)
+			}
+		})
+	}
+}
+
+func TestWorkflow_PaginationWithSections(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{
+			name: "sections before the more item on the first page",
+			args: []string{"query"},
+			want: []string{"title1", "title2", "section", "s-1", "1 more results…"},
+		},
+		{
+			name: "no sections on the next page",
+			args: []string{"query #page:2"},
+			want: []string{"title3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := testWorkflow(WithMaxResults(2), WithPagination(), WithArguments(tt.args...))
+			w.Append(
+				NewItem().Title("title1"),
+				NewItem().Title("title2"),
+				NewItem().Title("title3"),
+			)
+			w.AppendSection(NewSection("section").Items(NewItem().Title("s-1")))
+
+			got := new(ScriptFilter)
+			if err := got.UnmarshalJSON(w.Bytes()); err != nil {
+				t.Fatal(err)
+			}
+			assertTitles(t, tt.want, got.items)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package alfred
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Query is a structured search query parsed from input.
+// e.g. `foo "bar baz" subtitle:qux -quux --all` has the terms foo and "bar baz",
+// the filter subtitle:qux, the exclude quux and the flag all
+type Query struct {
+	// Terms are words and quoted phrases to search
+	Terms []string
+	// Excludes are words and phrases prefixed by `-`
+	Excludes []string
+	// Filters are `key:value` pairs. keys are lower case
+	Filters map[string][]string
+	// Flags are `--flag` or `--flag=value`. The value of `--flag` is empty
+	Flags map[string]string
+}
+
+// ParseQuery parses a query string
+func ParseQuery(s string) *Query {
+	q := &Query{
+		Terms:    []string{},
+		Excludes: []string{},
+		Filters:  map[string][]string{},
+		Flags:    map[string]string{},
+	}
+
+	for _, t := range tokenizeQuery(s) {
+		q.add(t)
+	}
+	return q
+}
+
+// Query returns the parsed query of input args
+func (w *Workflow) Query() *Query {
+	return ParseQuery(strings.Join(w.Args(), " "))
+}
+
+// Text returns terms joined with space for free text search
+func (q *Query) Text() string {
+	return strings.Join(q.Terms, " ")
+}
+
+// Filter returns values of the filter key
+func (q *Query) Filter(key string) []string {
+	return q.Filters[strings.ToLower(key)]
+}
+
+// HasFlag returns true if the flag is specified
+func (q *Query) HasFlag(name string) bool {
+	_, ok := q.Flags[name]
+	return ok
+}
+
+type queryToken struct {
+	text string
+	// quoted is true if the token starts with a quote
+	quoted bool
+}
+
+func (q *Query) add(t queryToken) {
+	s := t.text
+	switch {
+	case t.quoted:
+		q.Terms = append(q.Terms, s)
+	case strings.HasPrefix(s, "--") && len(s) > 2:
+		name, value, _ := strings.Cut(s[2:], "=")
+		q.Flags[name] = value
+	case strings.HasPrefix(s, "-") && len(s) > 1:
+		q.Excludes = append(q.Excludes, s[1:])
+	case strings.Index(s, ":") > 0 && !strings.HasSuffix(s, ":"):
+		key, value, _ := strings.Cut(s, ":")
+		key = strings.ToLower(key)
+		q.Filters[key] = append(q.Filters[key], value)
+	default:
+		q.Terms = append(q.Terms, s)
+	}
+}
+
+// tokenizeQuery splits s by spaces except within double quotes.
+// Quotes are removed from tokens
+func tokenizeQuery(s string) []queryToken {
+	var tokens []queryToken
+	var b strings.Builder
+	inQuote, quoted, started := false, false, false
+
+	flush := func() {
+		if started {
+			tokens = append(tokens, queryToken{text: b.String(), quoted: quoted})
+		}
+		b.Reset()
+		quoted, started = false, false
+	}
+
+	for _, r := range s {
+		switch {
+		case r == '"':
+			if !started {
+				quoted = true
+			}
+			started = true
+			inQuote = !inQuote
+		case unicode.IsSpace(r) && !inQuote:
+			flush()
+		default:
+			started = true
+			b.WriteRune(r)
+		}
+	}
+	flush()
+	return tokens
+}
+
+// FilterQuery filters items by the query.
+// Terms are searched on title like Filter with opts, filters match the item property
+// such as `subtitle:foo` by case-insensitive substring and excludes remove items containing them in title.
+// Unknown filter keys are ignored so that workflows can use them for their own purposes.
+// WithFolding applies to filters and excludes as well as terms
+func (i Items) FilterQuery(q *Query, opts ...FilterOption) Items {
+	items := i.Filter(q.Text(), opts...)
+	normalize := queryNormalizer(newFilterConfig(opts...).folding)
+	for key, values := range q.Filters {
+		property, ok := ParseItemProperty(key)
+		if !ok {
+			continue
+		}
+		for _, value := range values {
+			v := normalize(value)
+			items = filterByItemProperty(items, func(s string) bool {
+				return strings.Contains(normalize(s), v)
+			}, property)
+		}
+	}
+	for _, exclude := range q.Excludes {
+		v := normalize(exclude)
+		items = filterByItemProperty(items, func(s string) bool {
+			return !strings.Contains(normalize(s), v)
+		}, ItemPropertyTitle)
+	}
+	return items
+}
+
+// queryNormalizer returns a function folding s by f and lower-casing it
+func queryNormalizer(f Folding) func(s string) string {
+	fold := f.folder()
+	return func(s string) string {
+		if fold != nil {
+			s = fold(s)
+		}
+		return strings.ToLower(s)
+	}
+}
+
+// FilterQuery filters items and sections by the query
+func (w *Workflow) FilterQuery(q *Query, opts ...FilterOption) *Workflow {
+	w.items = w.items.FilterQuery(q, opts...)
+	w.eachSection(func(i Items) Items { return i.FilterQuery(q, opts...) })
+	return w
+}
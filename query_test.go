@@ -0,0 +1,111 @@
+package alfred
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  *Query
+	}{
+		{
+			name:  "empty",
+			input: "",
+			want: &Query{
+				Terms: []string{}, Excludes: []string{},
+				Filters: map[string][]string{}, Flags: map[string]string{},
+			},
+		},
+		{
+			name:  "all tokens",
+			input: `foo "bar baz" Subtitle:qux -quux -"a b" --all --sort=name`,
+			want: &Query{
+				Terms:    []string{"foo", "bar baz"},
+				Excludes: []string{"quux", "a b"},
+				Filters:  map[string][]string{"subtitle": {"qux"}},
+				Flags:    map[string]string{"all": "", "sort": "name"},
+			},
+		},
+		{
+			name:  "quoted filter value and quoted term",
+			input: `title:"hello world" "key:value" key: -`,
+			want: &Query{
+				Terms:    []string{"key:value", "key:", "-"},
+				Excludes: []string{},
+				Filters:  map[string][]string{"title": {"hello world"}},
+				Flags:    map[string]string{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseQuery(tt.input)
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Errorf("want: %+v\ngot: %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestItems_FilterQuery(t *testing.T) {
+	items := Items{
+		NewItem().Title("foo bar").Subtitle("apple"),
+		NewItem().Title("foo baz").Subtitle("banana"),
+		NewItem().Title("foo qux").Subtitle("apple pie"),
+		NewItem().Title("other").Subtitle("apple"),
+	}
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{name: "terms", query: "foo", want: []string{"foo bar", "foo baz", "foo qux"}},
+		{name: "filter", query: "foo subtitle:APPLE", want: []string{"foo bar", "foo qux"}},
+		{name: "exclude", query: "foo subtitle:apple -bar", want: []string{"foo qux"}},
+		{name: "unknown filter is ignored", query: "foo owner:me -qux -baz", want: []string{"foo bar"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := items.FilterQuery(ParseQuery(tt.query)).SortBy(ItemPropertyTitle)
+			assertTitles(t, tt.want, got)
+		})
+	}
+}
+
+func TestItems_FilterQueryWithFolding(t *testing.T) {
+	items := Items{
+		NewItem().Title("Crème brûlée").Subtitle("Café"),
+		NewItem().Title("Tea").Subtitle("Café"),
+		NewItem().Title("Coffee").Subtitle("bar"),
+	}
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{name: "filter", query: "subtitle:cafe", want: []string{"Crème brûlée", "Tea"}},
+		{name: "exclude", query: "subtitle:cafe -creme", want: []string{"Tea"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := items.FilterQuery(ParseQuery(tt.query)); len(got) == len(tt.want) {
+				t.Errorf("unexpected result without folding %v", titles(got))
+			}
+			got := items.FilterQuery(ParseQuery(tt.query), WithFolding(FoldAll)).SortBy(ItemPropertyTitle)
+			assertTitles(t, tt.want, got)
+		})
+	}
+}
+
+func TestWorkflow_Query(t *testing.T) {
+	t.Run("parse args", func(t *testing.T) {
+		w := testWorkflow(WithArguments("foo", "--all"))
+		got := w.Query()
+		if got.Text() != "foo" || !got.HasFlag("all") {
+			t.Errorf("unexpected query %+v", got)
+		}
+	})
+}
@@ -0,0 +1,160 @@
+package alfred
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"time"
+)
+
+const (
+	// DefaultRevalidateRerun is the rerun interval while refreshing items in the background
+	DefaultRevalidateRerun Rerun = 1
+	// DefaultRevalidateBackoff is the duration not to retry refreshing after a failure
+	DefaultRevalidateBackoff = time.Minute
+)
+
+const (
+	revalidateJobSuffix     = "-revalidate"
+	revalidateFailureSuffix = ".revalidate-failure"
+	// revalidateEnvKey holds the cache key refreshed by the job
+	revalidateEnvKey = "go_alfred_revalidate_key"
+)
+
+// RefreshFunc fetches fresh items and appends them to the workflow
+type RefreshFunc func(w *Workflow) error
+
+// RevalidateOption configures StaleWhileRevalidate
+type RevalidateOption func(*revalidateConfig)
+
+type revalidateConfig struct {
+	rerun   Rerun
+	item    *Item
+	cmd     *exec.Cmd
+	backoff time.Duration
+}
+
+// WithRevalidateRerun changes the rerun interval while refreshing
+func WithRevalidateRerun(r Rerun) RevalidateOption {
+	return func(c *revalidateConfig) {
+		c.rerun = r
+	}
+}
+
+// WithRefreshingItem shows the item as system information while refreshing e.g. "refreshing…"
+func WithRefreshingItem(item *Item) RevalidateOption {
+	return func(c *revalidateConfig) {
+		c.item = item
+	}
+}
+
+// WithRevalidateBackoff changes the duration not to retry refreshing after a failure.
+// Stale items are served without Rerun during the duration
+func WithRevalidateBackoff(d time.Duration) RevalidateOption {
+	return func(c *revalidateConfig) {
+		c.backoff = d
+	}
+}
+
+// WithRevalidateCommand changes the command started as the refresh job.
+// The command should call StaleWhileRevalidate with the same key.
+// The workflow itself with the same arguments is started by default
+func WithRevalidateCommand(cmd *exec.Cmd) RevalidateOption {
+	return func(c *revalidateConfig) {
+		c.cmd = cmd
+	}
+}
+
+// StaleWhileRevalidate loads cached items and refreshes them in the background if they are older than maxAge.
+// Stale items are returned immediately and a job calling refresh is started.
+// While the job is running, Rerun is set so that Alfred reloads the fresh items once they are stored.
+// In the job process, refresh is called with the cleared workflow,
+// the items are stored and the process exits.
+// An empty key calls refresh synchronously as the cache is always expired
+func (c *Cache) StaleWhileRevalidate(maxAge time.Duration, refresh RefreshFunc, opts ...RevalidateOption) error {
+	conf := &revalidateConfig{
+		rerun:   DefaultRevalidateRerun,
+		backoff: DefaultRevalidateBackoff,
+	}
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		opt(conf)
+	}
+
+	if c.key == "" {
+		return refresh(c.wf)
+	}
+
+	job := c.wf.Job(c.key + revalidateJobSuffix)
+	// other jobs of the workflow are also daemon children so the key is checked
+	if job.IsJob() && os.Getenv(revalidateEnvKey) == c.key {
+		c.revalidate(refresh)
+		return nil
+	}
+
+	err := c.MaxAge(maxAge).Load()
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, ErrCacheExpired) {
+		return err
+	}
+
+	// serve stale items if exist
+	if err := c.icache.load(&c.wf.items); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if c.wf.customEnvs.searchIndex {
+		c.loadIndex()
+	}
+
+	failure := newCache(c.key+revalidateFailureSuffix, JSONCodec)
+	if !failure.expired(conf.backoff) {
+		c.wf.sLogger().Warnf("skip refreshing the cache as the last refresh failed within %s", conf.backoff)
+		return nil
+	}
+
+	if !job.IsRunning() {
+		cmd := conf.cmd
+		if cmd == nil {
+			cmd = exec.Command(os.Args[0], os.Args[1:]...)
+		}
+		cmd.Env = append(cmd.Env, revalidateEnvKey+"="+c.key)
+		if _, err := job.Start(cmd); err != nil {
+			return err
+		}
+		c.wf.sLogger().Debugf("started a job %s to refresh the cache", job.Name())
+	}
+
+	c.wf.Rerun(conf.rerun)
+	if conf.item != nil {
+		c.wf.SetSystemInfo(conf.item)
+	}
+	return nil
+}
+
+// revalidate refreshes and stores items in the job process then exits.
+// A failure is recorded so that the next runs do not start jobs repeatedly
+func (c *Cache) revalidate(refresh RefreshFunc) {
+	failure := newCache(c.key+revalidateFailureSuffix, JSONCodec)
+	c.wf.Clear()
+	err := refresh(c.wf)
+	if err == nil {
+		err = c.Store()
+	}
+	if err != nil {
+		c.wf.sLogger().Errorln("failed to refresh the cache:", err)
+		if serr := failure.store(err.Error()); serr != nil {
+			c.wf.sLogger().Errorln("failed to record the refresh failure:", serr)
+		}
+		osExit(1)
+		return
+	}
+
+	if err := failure.clear(); err != nil {
+		c.wf.sLogger().Warnln("failed to clear the refresh failure:", err)
+	}
+	osExit(0)
+}
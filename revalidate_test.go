@@ -0,0 +1,201 @@
+package alfred
+
+import (
+	"errors"
+	"os/exec"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func storeTestCache(t *testing.T, key string, items ...*Item) Cacher {
+	t.Helper()
+	c := testWorkflow().Append(items...).Cache(key)
+	if err := c.Store(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = c.Clear() })
+	return c
+}
+
+func TestCache_StaleWhileRevalidate(t *testing.T) {
+	key := "test-revalidate"
+	noRefresh := func(t *testing.T) RefreshFunc {
+		return func(*Workflow) error {
+			t.Error("refresh should not be called")
+			return nil
+		}
+	}
+
+	t.Run("fresh cache", func(t *testing.T) {
+		storeTestCache(t, key, NewItem().Title("cached"))
+		wf := testWorkflow()
+		if err := wf.Cache(key).StaleWhileRevalidate(time.Minute, noRefresh(t)); err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{"cached"}; !reflect.DeepEqual(titles(wf.items), want) {
+			t.Errorf("want %v got %v", want, titles(wf.items))
+		}
+		if wf.rerun != 0 {
+			t.Errorf("unexpected rerun %v", wf.rerun)
+		}
+	})
+
+	t.Run("stale cache", func(t *testing.T) {
+		storeTestCache(t, key, NewItem().Title("stale"))
+		wf := testWorkflow()
+		cmd := exec.Command("sleep", "10")
+		refreshing := NewItem().Title("refreshing…")
+		err := wf.Cache(key).StaleWhileRevalidate(0, noRefresh(t),
+			WithRevalidateCommand(cmd),
+			WithRevalidateRerun(0.5),
+			WithRefreshingItem(refreshing),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		job := wf.Job(key + revalidateJobSuffix)
+		t.Cleanup(func() {
+			_ = job.Terminate()
+			_ = cmd.Wait()
+		})
+
+		if want := []string{"stale"}; !reflect.DeepEqual(titles(wf.items), want) {
+			t.Errorf("want %v got %v", want, titles(wf.items))
+		}
+		if wf.rerun != 0.5 {
+			t.Errorf("want rerun 0.5 got %v", wf.rerun)
+		}
+		if len(wf.system) != 1 || wf.system[0] != refreshing {
+			t.Errorf("refreshing item should be set %v", titles(wf.system))
+		}
+		if !job.IsRunning() {
+			t.Error("refresh job should be running")
+		}
+		if !containsString(cmd.Env, revalidateEnvKey+"="+key) {
+			t.Error("refresh job should be marked with the key")
+		}
+
+		// the running job is not started twice
+		wf2 := testWorkflow()
+		if err := wf2.Cache(key).StaleWhileRevalidate(0, noRefresh(t), WithRevalidateCommand(exec.Command("false"))); err != nil {
+			t.Fatal(err)
+		}
+		if wf2.rerun != DefaultRevalidateRerun {
+			t.Errorf("want default rerun got %v", wf2.rerun)
+		}
+	})
+
+	t.Run("refresh in job", func(t *testing.T) {
+		storeTestCache(t, key, NewItem().Title("stale"))
+		t.Setenv("DAEMON_CHILD_FLAG", "child")
+		t.Setenv(revalidateEnvKey, key)
+		code := -1
+		orig := osExit
+		osExit = func(c int) { code = c }
+		t.Cleanup(func() { osExit = orig })
+
+		wf := testWorkflow()
+		err := wf.Cache(key).StaleWhileRevalidate(time.Minute, func(w *Workflow) error {
+			w.Append(NewItem().Title("fresh"))
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code != 0 {
+			t.Errorf("want exit code 0 got %d", code)
+		}
+
+		got := testWorkflow()
+		if err := got.Cache(key).MaxAge(time.Minute).Load(); err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{"fresh"}; !reflect.DeepEqual(titles(got.items), want) {
+			t.Errorf("want %v got %v", want, titles(got.items))
+		}
+
+		code = -1
+		err = wf.Cache(key).StaleWhileRevalidate(time.Minute, func(w *Workflow) error {
+			return errors.New("refresh error")
+		})
+		if err != nil || code != 1 {
+			t.Errorf("want exit code 1 got %d, %v", code, err)
+		}
+
+		// the next run serves stale items without starting a job and rerun
+		t.Setenv("DAEMON_CHILD_FLAG", "")
+		t.Setenv(revalidateEnvKey, "")
+		cmd := exec.Command("false")
+		swf := testWorkflow()
+		if err := swf.Cache(key).StaleWhileRevalidate(0, noRefresh(t), WithRevalidateCommand(cmd)); err != nil {
+			t.Fatal(err)
+		}
+		if cmd.Process != nil {
+			t.Error("a job should not be started after a failure")
+		}
+		if swf.rerun != 0 {
+			t.Errorf("rerun should not be set after a failure: %v", swf.rerun)
+		}
+		if want := []string{"fresh"}; !reflect.DeepEqual(titles(swf.items), want) {
+			t.Errorf("want %v got %v", want, titles(swf.items))
+		}
+
+		// retry after the backoff
+		bwf := testWorkflow()
+		err = bwf.Cache(key).StaleWhileRevalidate(0, noRefresh(t),
+			WithRevalidateCommand(cmd), WithRevalidateBackoff(0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cmd.Process == nil {
+			t.Error("a job should be started after the backoff")
+		} else {
+			_ = cmd.Wait()
+		}
+	})
+
+	t.Run("other job", func(t *testing.T) {
+		storeTestCache(t, key, NewItem().Title("stale"))
+		t.Setenv("DAEMON_CHILD_FLAG", "child")
+		t.Setenv(revalidateEnvKey, "other")
+		orig := osExit
+		osExit = func(c int) { t.Errorf("unexpected exit %d", c) }
+		t.Cleanup(func() { osExit = orig })
+
+		cmd := exec.Command("false")
+		wf := testWorkflow()
+		if err := wf.Cache(key).StaleWhileRevalidate(0, noRefresh(t), WithRevalidateCommand(cmd)); err != nil {
+			t.Fatal(err)
+		}
+		if cmd.Process != nil {
+			t.Error("a job should not start another job")
+		}
+		if want := []string{"stale"}; !reflect.DeepEqual(titles(wf.items), want) {
+			t.Errorf("want %v got %v", want, titles(wf.items))
+		}
+	})
+
+	t.Run("empty key", func(t *testing.T) {
+		wf := testWorkflow()
+		err := wf.Cache("").StaleWhileRevalidate(time.Minute, func(w *Workflow) error {
+			w.Append(NewItem().Title("fresh"))
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{"fresh"}; !reflect.DeepEqual(titles(wf.items), want) {
+			t.Errorf("want %v got %v", want, titles(wf.items))
+		}
+	})
+}
+
+func containsString(list []string, v string) bool {
+	for _, s := range list {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
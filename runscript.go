@@ -0,0 +1,149 @@
+package alfred
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// RunScriptOutput JSON Format for Run Script actions.
+// It passes arg and variables downstream and overrides configurations of the next objects
+// see https://www.alfredapp.com/help/workflows/utilities/json/
+type RunScriptOutput struct {
+	arg       []string
+	config    map[string]interface{}
+	variables Variables
+}
+
+// NewRunScriptOutput creates a new RunScriptOutput
+func NewRunScriptOutput() *RunScriptOutput {
+	return new(RunScriptOutput)
+}
+
+// Arg adds arg passed to the next objects
+func (r *RunScriptOutput) Arg(arg string) *RunScriptOutput {
+	r.arg = newArgs(arg)
+	return r
+}
+
+// Args adds multiple arguments passed to the next objects
+func (r *RunScriptOutput) Args(args ...string) *RunScriptOutput {
+	r.arg = newArgs(args...)
+	return r
+}
+
+// Config adds a configuration overriding the next object
+func (r *RunScriptOutput) Config(k string, v interface{}) *RunScriptOutput {
+	if k == "" {
+		return r
+	}
+	if r.config == nil {
+		r.config = make(map[string]interface{})
+	}
+	r.config[k] = v
+	return r
+}
+
+// Variables adds variables passed to the next objects
+func (r *RunScriptOutput) Variables(vars Variables) *RunScriptOutput {
+	for k, v := range vars {
+		r.Variable(k, v)
+	}
+	return r
+}
+
+// Variable adds single key/value pair
+func (r *RunScriptOutput) Variable(k, v string) *RunScriptOutput {
+	if k == "" {
+		return r
+	}
+	if r.variables == nil {
+		r.variables = make(Variables)
+	}
+	r.variables[k] = v
+	return r
+}
+
+func (r *RunScriptOutput) Bytes() []byte {
+	res, err := r.MarshalJSON()
+	if err != nil {
+		return fatalError(err)
+	}
+
+	return res
+}
+
+func (r *RunScriptOutput) String() string {
+	return string(r.Bytes())
+}
+
+func (r *RunScriptOutput) MarshalJSON() ([]byte, error) {
+	out := r.internal()
+	return json.Marshal(out)
+}
+
+func (r *RunScriptOutput) UnmarshalJSON(data []byte) error {
+	in := &iRunScriptOutput{}
+	err := json.Unmarshal(data, in)
+	if err != nil {
+		return err
+	}
+
+	*r = *in.external()
+	return nil
+}
+
+type iRunScriptOutput struct {
+	AlfredWorkflow iAlfredWorkflow `json:"alfredworkflow"`
+}
+
+type iAlfredWorkflow struct {
+	Arg       iStrings               `json:"arg,omitempty"`
+	Config    map[string]interface{} `json:"config,omitempty"`
+	Variables Variables              `json:"variables,omitempty"`
+}
+
+func (r *RunScriptOutput) internal() *iRunScriptOutput {
+	return &iRunScriptOutput{
+		AlfredWorkflow: iAlfredWorkflow{
+			Arg:       r.arg,
+			Config:    r.config,
+			Variables: r.variables,
+		},
+	}
+}
+
+func (r *iRunScriptOutput) external() *RunScriptOutput {
+	return &RunScriptOutput{
+		arg:       r.AlfredWorkflow.Arg,
+		config:    r.AlfredWorkflow.Config,
+		variables: r.AlfredWorkflow.Variables,
+	}
+}
+
+// writeRunScriptTo writes Run Script JSON or errors as plain text
+func (w *Workflow) writeRunScriptTo(out io.Writer) (int64, error) {
+	if len(w.err) > 0 {
+		n, err := io.WriteString(out, errorText(w.err))
+		return int64(n), err
+	}
+
+	b, err := w.runScript.MarshalJSON()
+	if err != nil {
+		return 0, err
+	}
+	n, err := out.Write(b)
+	return int64(n), err
+}
+
+// errorText renders error items as lines of title and subtitle
+func errorText(items Items) string {
+	lines := make([]string, 0, len(items)*2)
+	for _, item := range items {
+		lines = append(lines, item.title)
+		if item.subtitle != "" {
+			lines = append(lines, item.subtitle)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
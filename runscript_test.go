@@ -0,0 +1,100 @@
+package alfred
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestRunScriptOutput(t *testing.T) {
+	tests := []struct {
+		name     string
+		filepath string
+		output   *RunScriptOutput
+	}{
+		{
+			name:     "marshal run script output",
+			filepath: testFilePath("test_runscript_output.json"),
+			output: NewRunScriptOutput().
+				Args("arg1", "arg2").
+				Config("searchurl", "https://www.alfredapp.com/search/{query}").
+				Config("spaces", true).
+				Variable("key1", "value1").
+				Variables(Variables{"key2": "value2"}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := os.ReadFile(tt.filepath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := NewRunScriptOutput()
+			if err := json.Unmarshal(data, want); err != nil {
+				t.Fatal(err)
+			}
+			if diff := Diff(want, tt.output); diff != "" {
+				t.Errorf("-want +got\n%+v", diff)
+			}
+
+			// marshal/unmarshal test
+			got := NewRunScriptOutput()
+			if err := got.UnmarshalJSON(tt.output.Bytes()); err != nil {
+				t.Fatal(err)
+			}
+			if diff := Diff(want, got); diff != "" {
+				t.Errorf("-want +got\n%+v", diff)
+			}
+		})
+	}
+}
+
+func TestWorkflow_RunScriptOutput(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         []Option
+		fn           func(*Workflow) error
+		initializers []Initializer
+		want         string
+	}{
+		{
+			name: "output run script json",
+			fn: func(w *Workflow) error {
+				w.SetRunScriptOutput(NewRunScriptOutput().Arg("arg")).Output()
+				return nil
+			},
+			want: `{"alfredworkflow":{"arg":"arg"}}` + "\n",
+		},
+		{
+			name: "output error as plain text",
+			fn: func(w *Workflow) error {
+				w.SetRunScriptOutput(NewRunScriptOutput().Arg("arg"))
+				return errors.New("error message")
+			},
+			want: "error message\nPlease check workflow debug log\n",
+		},
+		{
+			name: "output initializer error as plain text with the option",
+			opts: []Option{WithRunScriptOutput()},
+			fn: func(w *Workflow) error {
+				w.SetRunScriptOutput(NewRunScriptOutput().Arg("arg")).Output()
+				return nil
+			},
+			initializers: []Initializer{new(testInitializer)},
+			want:         "initializer error\nPlease check workflow debug log\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outBuf := new(bytes.Buffer)
+			w := testWorkflow(append(tt.opts, WithOutWriter(outBuf))...)
+			w.Run(tt.fn, tt.initializers...)
+			if got := outBuf.String(); got != tt.want {
+				t.Errorf("want: %q\ngot: %q", tt.want, got)
+			}
+		})
+	}
+}
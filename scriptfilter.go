@@ -1,20 +1,24 @@
 package alfred
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 )
 
 const (
-	fatalErrorJSON = `{"items": [{"title": "Fatal Error","subtitle": "%s",}]}`
+	fatalErrorJSON = `{"items": [{"title": "Fatal Error","subtitle": %s}]}`
 	sentMessage    = "The workflow has already sent"
 )
 
 // ScriptFilter JSON Format
 type ScriptFilter struct {
-	rerun     Rerun
-	variables Variables
-	items     Items
+	rerun         Rerun
+	variables     Variables
+	cache         *CacheControl
+	skipKnowledge bool
+	items         Items
 }
 
 // NewScriptFilter creates a new ScriptFilter
@@ -53,6 +57,16 @@ func (s *ScriptFilter) Rerun(i Rerun) {
 	s.rerun = i
 }
 
+// CacheControl sets cache object to let Alfred cache the results
+func (s *ScriptFilter) CacheControl(c *CacheControl) {
+	s.cache = c
+}
+
+// SkipKnowledge lets Alfred keep the order of items instead of ordering them by knowledge
+func (s *ScriptFilter) SkipKnowledge(b bool) {
+	s.skipKnowledge = b
+}
+
 // Clear remove all items
 func (s *ScriptFilter) Clear() {
 	s.items = make(Items, 0, cap(s.items))
@@ -61,12 +75,18 @@ func (s *ScriptFilter) Clear() {
 func (s *ScriptFilter) Bytes() []byte {
 	res, err := s.MarshalJSON()
 	if err != nil {
-		return []byte(fmt.Sprintf(fatalErrorJSON, err.Error()))
+		return fatalError(err)
 	}
 
 	return res
 }
 
+// fatalError returns JSON of the error item. The message is escaped as JSON string
+func fatalError(err error) []byte {
+	msg, _ := json.Marshal(err.Error())
+	return []byte(fmt.Sprintf(fatalErrorJSON, msg))
+}
+
 func (s *ScriptFilter) String() string {
 	return string(s.Bytes())
 }
@@ -76,13 +96,143 @@ func (s *ScriptFilter) IsEmpty() bool {
 	return len(s.items) == 0
 }
 
+// WriteTo writes JSON of ScriptFilter to out item by item without building whole JSON in memory
+func (s *ScriptFilter) WriteTo(out io.Writer) (int64, error) {
+	return s.writeTo(out, false, itemGroup{items: s.items})
+}
+
+// itemGroup is items written in order.
+// If fillUID is true, missing uids are derived on writing and
+// if tagUsage is true, uids are passed as UsageVariable without modifying the items
+type itemGroup struct {
+	items    Items
+	fillUID  bool
+	tagUsage bool
+}
+
+// writeTo writes JSON of ScriptFilter with groups of items instead of s.items.
+// The output is the same as MarshalJSON except skipknowledge and uids changed on writing.
+// All items are validated before writing so that partial JSON is not written on errors
+func (s *ScriptFilter) writeTo(out io.Writer, skipKnowledge bool, groups ...itemGroup) (int64, error) {
+	if err := s.cache.Validate(); err != nil {
+		return 0, err
+	}
+	for _, g := range groups {
+		for _, item := range g.items {
+			if err := item.mods.Validate(); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	sw := newStreamWriter(out)
+	sw.write("{")
+	if s.rerun != 0 {
+		sw.field("rerun", s.rerun)
+	}
+	if len(s.variables) > 0 {
+		sw.field("variables", s.variables)
+	}
+	if s.cache != nil {
+		sw.field("cache", s.cache.internal())
+	}
+	if s.skipKnowledge || skipKnowledge {
+		sw.field("skipknowledge", true)
+	}
+	sw.write(`"items":[`)
+	first := true
+	for _, g := range groups {
+		for _, item := range g.items {
+			if !first {
+				sw.write(",")
+			}
+			first = false
+			sw.item(item, g)
+		}
+	}
+	sw.write("]}")
+	return sw.n, sw.err
+}
+
+// streamWriter writes JSON tokens and keeps the first error
+type streamWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+	buf *bytes.Buffer
+	enc *json.Encoder
+}
+
+func newStreamWriter(w io.Writer) *streamWriter {
+	buf := new(bytes.Buffer)
+	return &streamWriter{
+		w:   w,
+		buf: buf,
+		enc: json.NewEncoder(buf),
+	}
+}
+
+func (sw *streamWriter) write(s string) {
+	sw.writeBytes([]byte(s))
+}
+
+func (sw *streamWriter) writeBytes(b []byte) {
+	if sw.err != nil {
+		return
+	}
+	n, err := sw.w.Write(b)
+	sw.n += int64(n)
+	sw.err = err
+}
+
+// value encodes v with the reusable buffer to reduce allocations
+func (sw *streamWriter) value(v interface{}) {
+	if sw.err != nil {
+		return
+	}
+	sw.buf.Reset()
+	if err := sw.enc.Encode(v); err != nil {
+		sw.err = err
+		return
+	}
+	// remove the newline added by the encoder
+	sw.writeBytes(bytes.TrimSuffix(sw.buf.Bytes(), []byte("\n")))
+}
+
+func (sw *streamWriter) item(i *Item, g itemGroup) {
+	in := i.internal()
+	if g.fillUID && in.UID == "" {
+		in.UID = deriveUID(i)
+	}
+	if _, ok := in.Variables[UsageVariable]; g.tagUsage && in.UID != "" && !ok {
+		vars := make(Variables, len(in.Variables)+1)
+		for k, v := range in.Variables {
+			vars[k] = v
+		}
+		vars[UsageVariable] = in.UID
+		in.Variables = vars
+	}
+	sw.value(in)
+}
+
+func (sw *streamWriter) field(name string, v interface{}) {
+	sw.write(`"` + name + `":`)
+	sw.value(v)
+	sw.write(",")
+}
+
 type iScriptFilter struct {
-	Rerun     Rerun     `json:"rerun,omitempty"`
-	Variables Variables `json:"variables,omitempty"`
-	Items     iItems    `json:"items"`
+	Rerun         Rerun          `json:"rerun,omitempty"`
+	Variables     Variables      `json:"variables,omitempty"`
+	Cache         *iCacheControl `json:"cache,omitempty"`
+	SkipKnowledge bool           `json:"skipknowledge,omitempty"`
+	Items         iItems         `json:"items"`
 }
 
 func (s *ScriptFilter) MarshalJSON() ([]byte, error) {
+	if err := s.cache.Validate(); err != nil {
+		return nil, err
+	}
 	out := s.internal()
 	return json.Marshal(out)
 }
@@ -95,21 +245,25 @@ func (s *ScriptFilter) UnmarshalJSON(data []byte) error {
 	}
 
 	*s = *in.external()
-	return nil
+	return s.cache.Validate()
 }
 
 func (s *ScriptFilter) internal() *iScriptFilter {
 	return &iScriptFilter{
-		Rerun:     s.rerun,
-		Variables: s.variables,
-		Items:     s.items.internal(),
+		Rerun:         s.rerun,
+		Variables:     s.variables,
+		Cache:         s.cache.internal(),
+		SkipKnowledge: s.skipKnowledge,
+		Items:         s.items.internal(),
 	}
 }
 
 func (s iScriptFilter) external() *ScriptFilter {
 	return &ScriptFilter{
-		rerun:     s.Rerun,
-		variables: s.Variables,
-		items:     s.Items.external(),
+		rerun:         s.Rerun,
+		variables:     s.Variables,
+		cache:         s.Cache.external(),
+		skipKnowledge: s.SkipKnowledge,
+		items:         s.Items.external(),
 	}
 }
@@ -0,0 +1,95 @@
+package alfred
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	// MinCacheSeconds is the minimum value of seconds Alfred accepts for cache
+	MinCacheSeconds = 5
+	// MaxCacheSeconds is the maximum value of seconds Alfred accepts for cache
+	MaxCacheSeconds = 86400
+)
+
+// CacheControl element tells Alfred to cache the results of the script filter
+type CacheControl struct {
+	seconds     int
+	looseReload bool
+}
+
+// NewCacheControl generates new cache control with seconds
+func NewCacheControl(seconds int) *CacheControl {
+	return &CacheControl{
+		seconds: seconds,
+	}
+}
+
+// Seconds adds time to live for cached data
+func (c *CacheControl) Seconds(s int) *CacheControl {
+	c.seconds = s
+	return c
+}
+
+// LooseReload adds loosereload.
+// Alfred returns the stale results and reloads them in the background if true
+func (c *CacheControl) LooseReload(b bool) *CacheControl {
+	c.looseReload = b
+	return c
+}
+
+// Validate returns an error if seconds is out of the range Alfred accepts
+func (c *CacheControl) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.seconds < MinCacheSeconds || c.seconds > MaxCacheSeconds {
+		return fmt.Errorf("cache seconds must be between %d and %d but got %d",
+			MinCacheSeconds, MaxCacheSeconds, c.seconds)
+	}
+	return nil
+}
+
+func (c *CacheControl) MarshalJSON() ([]byte, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	out := c.internal()
+	return json.Marshal(out)
+}
+
+func (c *CacheControl) UnmarshalJSON(data []byte) error {
+	in := &iCacheControl{}
+	err := json.Unmarshal(data, in)
+	if err != nil {
+		return err
+	}
+
+	*c = *in.external()
+	return c.Validate()
+}
+
+type iCacheControl struct {
+	Seconds     int  `json:"seconds"`
+	LooseReload bool `json:"loosereload,omitempty"`
+}
+
+func (c *CacheControl) internal() *iCacheControl {
+	if c == nil {
+		return nil
+	}
+	return &iCacheControl{
+		Seconds:     c.seconds,
+		LooseReload: c.looseReload,
+	}
+}
+
+func (c *iCacheControl) external() *CacheControl {
+	if c == nil {
+		return nil
+	}
+	return &CacheControl{
+		seconds:     c.Seconds,
+		looseReload: c.LooseReload,
+	}
+}
@@ -0,0 +1,67 @@
+package alfred
+
+import (
+	"testing"
+)
+
+func TestNewCacheControl(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    *CacheControl
+		seconds int
+		wantErr bool
+	}{
+		{
+			name: "new cache control",
+			want: &CacheControl{
+				seconds:     60,
+				looseReload: true,
+			},
+			seconds: 60,
+		},
+		{
+			name: "too short seconds",
+			want: &CacheControl{
+				seconds:     MinCacheSeconds - 1,
+				looseReload: true,
+			},
+			seconds: MinCacheSeconds - 1,
+			wantErr: true,
+		},
+		{
+			name: "too long seconds",
+			want: &CacheControl{
+				seconds:     MaxCacheSeconds + 1,
+				looseReload: true,
+			},
+			seconds: MaxCacheSeconds + 1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewCacheControl(tt.seconds).LooseReload(true)
+			if diff := Diff(tt.want, got); diff != "" {
+				t.Errorf("-want +got\n %s", diff)
+			}
+
+			// marshal/unmarshal test
+			b, err := got.MarshalJSON()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			got = new(CacheControl)
+			if err := got.UnmarshalJSON(b); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := Diff(tt.want, got); diff != "" {
+				t.Errorf("-want +got\n %s", diff)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package alfred
 
 import (
+	"bytes"
 	"encoding/json"
 	"os"
 	"testing"
@@ -89,3 +90,89 @@ func TestUnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestScriptFilterCacheControl(t *testing.T) {
+	tests := []struct {
+		description string
+		filepath    string
+		cache       *CacheControl
+		wantErr     bool
+	}{
+		{
+			description: "output cache object",
+			filepath:    testFilePath("test_scriptfilter_cache.json"),
+			cache:       NewCacheControl(3600).LooseReload(true),
+		},
+		{
+			description: "invalid cache object",
+			cache:       NewCacheControl(0),
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			wf := testWorkflow().Append(items01...).CacheControl(tt.cache)
+			got, err := wf.ScriptFilter.MarshalJSON()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			want, err := os.ReadFile(tt.filepath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := DiffOutput(want, got); diff != "" {
+				t.Errorf("-want +got\n%+v", diff)
+			}
+		})
+	}
+}
+
+func TestScriptFilterWriteTo(t *testing.T) {
+	tests := []struct {
+		description string
+		setup       func(sf *ScriptFilter)
+	}{
+		{
+			description: "empty script filter",
+			setup:       func(sf *ScriptFilter) {},
+		},
+		{
+			description: "all fields",
+			setup: func(sf *ScriptFilter) {
+				sf.Items(items04...)
+				sf.Items(items05...)
+				sf.Variables(scriptfilter01.variables)
+				sf.Rerun(1.5)
+				sf.CacheControl(NewCacheControl(60).LooseReload(true))
+				sf.SkipKnowledge(true)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			sf := NewScriptFilter()
+			tt.setup(sf)
+
+			buf := new(bytes.Buffer)
+			n, err := sf.WriteTo(buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if int(n) != buf.Len() {
+				t.Errorf("written bytes want %d got %d", buf.Len(), n)
+			}
+
+			want, err := sf.MarshalJSON()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.Bytes(); !bytes.Equal(want, got) {
+				t.Errorf("want: %s\ngot: %s", want, got)
+			}
+		})
+	}
+}
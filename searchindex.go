@@ -0,0 +1,213 @@
+package alfred
+
+import (
+	"hash/fnv"
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SearchIndex is a precomputed index of item titles to pre-filter candidates before fuzzy scoring.
+// Postings are built from characters of titles instead of trigrams
+// because a fuzzy match requires all characters of the query but not adjacent ones.
+// An index is bound to the items it is built from or loaded with and
+// it is ignored for other items e.g. after Sort or Append
+type SearchIndex struct {
+	items    Items
+	digest   uint64
+	folding  Folding
+	titles   []string
+	postings map[rune][]int32
+}
+
+type iSearchIndex struct {
+	Digest   uint64           `json:"digest"`
+	Folding  Folding          `json:"folding"`
+	Titles   []string         `json:"titles"`
+	Postings map[rune][]int32 `json:"postings"`
+}
+
+// WithSearchIndex stores a search index alongside Cache.Store and loads it with Cache.Load.
+// Workflow.Filter uses the loaded index while the items are not changed.
+// An index built from other items e.g. overwritten without the option is ignored.
+// The folding should be the same as one passed to Filter by WithFolding
+func WithSearchIndex(f Folding) Option {
+	return func(wf *Workflow) {
+		wf.customEnvs.searchIndex = true
+		wf.customEnvs.indexFolding = f
+	}
+}
+
+// WithIndex pre-filters items with the index built from the same items.
+// The index is used only with the default matcher and title field
+func WithIndex(idx *SearchIndex) FilterOption {
+	return func(c *filterConfig) {
+		c.index = idx
+	}
+}
+
+// BuildSearchIndex builds a search index of titles of items folded by f
+func BuildSearchIndex(items Items, f Folding) *SearchIndex {
+	fold := f.folder()
+	idx := &SearchIndex{
+		items:    items,
+		digest:   itemsDigest(items),
+		folding:  f,
+		titles:   make([]string, len(items)),
+		postings: make(map[rune][]int32),
+	}
+	for pos, item := range items {
+		title := searchValue(item, ItemPropertyTitle)
+		if fold != nil {
+			title = fold(title)
+		}
+		idx.titles[pos] = title
+		for _, r := range indexKeys(title) {
+			idx.postings[r] = append(idx.postings[r], int32(pos))
+		}
+	}
+	return idx
+}
+
+// Len returns the number of indexed items
+func (idx *SearchIndex) Len() int {
+	return len(idx.titles)
+}
+
+// bind associates the index with items if the index is built from the same titles and matches
+func (idx *SearchIndex) bind(items Items) bool {
+	if idx.Len() != len(items) || idx.digest != itemsDigest(items) {
+		return false
+	}
+	idx.items = items
+	return true
+}
+
+// usable returns true if the index is built from items
+func (idx *SearchIndex) usable(items Items) bool {
+	if idx == nil || len(idx.items) != len(items) || idx.Len() != len(items) {
+		return false
+	}
+	return len(items) == 0 || &idx.items[0] == &items[0]
+}
+
+// candidates returns positions of items containing all characters of the query in ascending order
+func (idx *SearchIndex) candidates(query string) []int32 {
+	keys := indexKeys(query)
+	lists := make([][]int32, 0, len(keys))
+	for _, r := range keys {
+		l, ok := idx.postings[r]
+		if !ok {
+			return nil
+		}
+		lists = append(lists, l)
+	}
+	if len(lists) == 0 {
+		return nil
+	}
+
+	sort.Slice(lists, func(a, b int) bool { return len(lists[a]) < len(lists[b]) })
+	ret := lists[0]
+	for _, l := range lists[1:] {
+		ret = intersect(ret, l)
+		if len(ret) == 0 {
+			return nil
+		}
+	}
+	return ret
+}
+
+// filterIndexed returns candidates of the query and their folded titles
+func (idx *SearchIndex) filterIndexed(query string) (Items, []string) {
+	positions := idx.candidates(query)
+	items := make(Items, len(positions))
+	titles := make([]string, len(positions))
+	for i, pos := range positions {
+		items[i] = idx.items[pos]
+		titles[i] = idx.titles[pos]
+	}
+	return items, titles
+}
+
+func (idx *SearchIndex) internal() *iSearchIndex {
+	return &iSearchIndex{
+		Digest:   idx.digest,
+		Folding:  idx.folding,
+		Titles:   idx.titles,
+		Postings: idx.postings,
+	}
+}
+
+func (idx *iSearchIndex) external() *SearchIndex {
+	return &SearchIndex{
+		digest:   idx.Digest,
+		folding:  idx.Folding,
+		titles:   idx.Titles,
+		postings: idx.Postings,
+	}
+}
+
+// itemsDigest returns a hash of titles and matches of items to detect an outdated index
+func itemsDigest(items Items) uint64 {
+	h := fnv.New64a()
+	for _, item := range items {
+		h.Write([]byte(item.title))
+		h.Write([]byte{0})
+		h.Write([]byte(item.match))
+		h.Write([]byte{0})
+	}
+	return h.Sum64()
+}
+
+// indexKeys returns unique case-insensitive keys of characters in s except spaces
+func indexKeys(s string) []rune {
+	keys := make([]rune, 0, utf8.RuneCountInString(s))
+	seen := make(map[rune]struct{}, cap(keys))
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		k := foldKey(r)
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// foldKey returns the smallest rune equivalent to r under simple case folding
+func foldKey(r rune) rune {
+	if r < utf8.RuneSelf {
+		if 'a' <= r && r <= 'z' {
+			return r - 'a' + 'A'
+		}
+		return r
+	}
+	min := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f < min {
+			min = f
+		}
+	}
+	return min
+}
+
+// intersect returns common values of sorted a and b
+func intersect(a, b []int32) []int32 {
+	ret := make([]int32, 0, len(a))
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			ret = append(ret, a[i])
+			i++
+			j++
+		}
+	}
+	return ret
+}
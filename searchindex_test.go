@@ -0,0 +1,130 @@
+package alfred
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSearchIndex_Filter(t *testing.T) {
+	items := Items{
+		NewItem().Title("go-alfred"),
+		NewItem().Title("Alfred Workflow"),
+		NewItem().Title("kubectl").Match("kubernetes kubectl"),
+		NewItem().Title("Café"),
+		NewItem().Title("ＧＯ"),
+		NewItem().Title("rust"),
+	}
+	tests := []struct {
+		name    string
+		folding Folding
+		opts    []FilterOption
+	}{
+		{name: "default"},
+		{name: "folding", folding: FoldAll, opts: []FilterOption{WithFolding(FoldAll)}},
+		{name: "title field", opts: []FilterOption{WithField(ItemPropertyTitle, 2)}},
+		{name: "subtitle field", opts: []FilterOption{WithField(ItemPropertySubtitle, 1)}},
+		{name: "matcher", opts: []FilterOption{WithMatcher(PrefixMatcher())}},
+	}
+	queries := []string{"go", "GO", "alf", "aw", "netes", "cafe", "rs", "x", "go alfred"}
+	for _, tt := range tests {
+		idx := BuildSearchIndex(items, tt.folding)
+		for _, q := range queries {
+			t.Run(tt.name+"/"+q, func(t *testing.T) {
+				want := items.Filter(q, tt.opts...)
+				got := items.Filter(q, append(tt.opts, WithIndex(idx))...)
+				if !reflect.DeepEqual(titles(want), titles(got)) {
+					t.Errorf("want %v got %v", titles(want), titles(got))
+				}
+			})
+		}
+	}
+}
+
+func TestSearchIndex_Candidates(t *testing.T) {
+	items := Items{
+		NewItem().Title("go-alfred"),
+		NewItem().Title("rust"),
+		NewItem().Title("golang"),
+	}
+	idx := BuildSearchIndex(items, 0)
+	if got, want := idx.candidates("GO"), []int32{0, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v got %v", want, got)
+	}
+	if got := idx.candidates("goz"); len(got) != 0 {
+		t.Errorf("want no candidates got %v", got)
+	}
+
+	if !idx.usable(items) {
+		t.Error("index should be usable for the items")
+	}
+	if idx.usable(items.SortBy(ItemPropertyTitle)) {
+		t.Error("index should not be usable for sorted items")
+	}
+}
+
+func TestCache_SearchIndex(t *testing.T) {
+	key := "test-index"
+	wf := testWorkflow(WithSearchIndex(FoldCase)).Append(
+		NewItem().Title("go-alfred"),
+		NewItem().Title("rust"),
+	)
+	c := wf.Cache(key)
+	if err := c.Store(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = c.Clear() })
+
+	gwf := testWorkflow(WithSearchIndex(FoldCase))
+	if err := gwf.Cache(key).MaxAge(time.Minute).Load(); err != nil {
+		t.Fatal(err)
+	}
+	if gwf.index == nil || !gwf.index.usable(gwf.items) {
+		t.Fatal("search index should be loaded")
+	}
+	gwf.Filter("go", WithFolding(FoldCase))
+	if want := []string{"go-alfred"}; !reflect.DeepEqual(titles(gwf.items), want) {
+		t.Errorf("want %v got %v", want, titles(gwf.items))
+	}
+
+	// an index built with another folding is not used
+	owf := testWorkflow(WithSearchIndex(FoldAll))
+	if err := owf.Cache(key).MaxAge(time.Minute).Load(); err != nil {
+		t.Fatal(err)
+	}
+	if owf.index != nil {
+		t.Error("outdated search index should not be loaded")
+	}
+}
+
+func TestCache_OutdatedSearchIndex(t *testing.T) {
+	key := "test-outdated-index"
+	c := testWorkflow(WithSearchIndex(0)).Append(
+		NewItem().Title("apple"),
+		NewItem().Title("banana"),
+	).Cache(key)
+	if err := c.Store(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = c.Clear() })
+
+	// overwrite items with the same number of items without the index
+	if err := testWorkflow().Append(
+		NewItem().Title("cherry"),
+		NewItem().Title("durian"),
+	).Cache(key).Store(); err != nil {
+		t.Fatal(err)
+	}
+
+	wf := testWorkflow(WithSearchIndex(0))
+	if err := wf.Cache(key).MaxAge(time.Minute).Load(); err != nil {
+		t.Fatal(err)
+	}
+	if wf.index != nil {
+		t.Error("outdated search index should not be loaded")
+	}
+	wf.Filter("cherry")
+	if want := []string{"cherry"}; !reflect.DeepEqual(titles(wf.items), want) {
+		t.Errorf("want %v got %v", want, titles(wf.items))
+	}
+}
@@ -0,0 +1,89 @@
+package alfred
+
+// Section is a group of items displayed under a header row.
+// Alfred has no native sections so that the header is an invalid item
+type Section struct {
+	title     string
+	icon      *Icon
+	items     Items
+	showEmpty bool
+}
+
+// NewSection generates new section with the header title
+func NewSection(title string) *Section {
+	return &Section{
+		title: title,
+		items: Items{},
+	}
+}
+
+// Icon adds icon of the header
+func (s *Section) Icon(icon *Icon) *Section {
+	s.icon = icon
+	return s
+}
+
+// Items appends items to the section
+func (s *Section) Items(items ...*Item) *Section {
+	s.items = append(s.items, items...)
+	return s
+}
+
+// ShowEmpty displays the header even if the section has no items.
+// Empty sections are hidden by default not to leave orphaned headers after filtering
+func (s *Section) ShowEmpty(b bool) *Section {
+	s.showEmpty = b
+	return s
+}
+
+// AppendSection appends sections displayed after the items of the workflow.
+// Filter, FilterByItemProperty, Sort and WithMaxResults apply to each section
+func (w *Workflow) AppendSection(s ...*Section) *Workflow {
+	for _, section := range s {
+		if section == nil {
+			continue
+		}
+		w.sections = append(w.sections, section)
+	}
+	return w
+}
+
+// sectionItems returns headers and items of sections in order.
+// groups are items of each section to output.
+// Sections are output only on the first page of pagination
+func (w *Workflow) sectionItems(groups []Items) []Items {
+	if w.Page() > 1 {
+		return nil
+	}
+	ret := make([]Items, 0, len(w.sections)*2)
+	for idx, s := range w.sections {
+		items := groups[idx]
+		if len(items) == 0 && !s.showEmpty {
+			continue
+		}
+
+		if limit := w.customEnvs.maxResults; limit > 0 && len(items) > limit {
+			items = items[:limit]
+		}
+		ret = append(ret, Items{s.header()}, items)
+	}
+	return ret
+}
+
+// header returns the header row of the section.
+// It has no autocomplete so that actioning it does nothing and keeps the query including a page token
+func (s *Section) header() *Item {
+	header := NewItem().
+		Title(s.title).
+		Icon(s.icon).
+		Valid(false)
+	header.header = true
+	return header
+}
+
+// eachSection applies fn to items of each section
+func (w *Workflow) eachSection(fn func(Items) Items) {
+	for _, s := range w.sections {
+		s.items = fn(s.items)
+	}
+}
@@ -0,0 +1,104 @@
+package alfred
+
+import (
+	"testing"
+)
+
+func TestWorkflow_AppendSection(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  []Option
+		setup func(w *Workflow)
+		want  []string
+	}{
+		{
+			name: "render headers after items",
+			setup: func(w *Workflow) {
+				w.Append(NewItem().Title("item"))
+				w.AppendSection(
+					NewSection("section1").Items(NewItem().Title("s1-1"), NewItem().Title("s1-2")),
+					NewSection("section2").Items(NewItem().Title("s2-1")),
+				)
+			},
+			want: []string{"item", "section1", "s1-1", "s1-2", "section2", "s2-1"},
+		},
+		{
+			name: "hide empty sections",
+			setup: func(w *Workflow) {
+				w.AppendSection(
+					NewSection("empty"),
+					NewSection("shown").ShowEmpty(true),
+					NewSection("section").Items(NewItem().Title("s-1")),
+				)
+			},
+			want: []string{"shown", "section", "s-1"},
+		},
+		{
+			name: "filter does not leave orphaned headers",
+			setup: func(w *Workflow) {
+				w.AppendSection(
+					NewSection("section1").Items(NewItem().Title("apple"), NewItem().Title("banana")),
+					NewSection("section2").Items(NewItem().Title("cherry")),
+				)
+				w.Filter("apple")
+			},
+			want: []string{"section1", "apple"},
+		},
+		{
+			name: "max results per section",
+			opts: []Option{WithMaxResults(1)},
+			setup: func(w *Workflow) {
+				w.AppendSection(
+					NewSection("section1").Items(NewItem().Title("s1-1"), NewItem().Title("s1-2")),
+					NewSection("section2").Items(NewItem().Title("s2-1"), NewItem().Title("s2-2")),
+				)
+			},
+			want: []string{"section1", "s1-1", "section2", "s2-1"},
+		},
+		{
+			name: "empty warning if all sections are empty",
+			setup: func(w *Workflow) {
+				w.SetEmptyWarning("empty warning", "")
+				w.AppendSection(NewSection("section1").Items(NewItem().Title("apple")))
+				w.Filter("cherry")
+			},
+			want: []string{"empty warning"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := testWorkflow(tt.opts...)
+			tt.setup(w)
+
+			got := new(ScriptFilter)
+			if err := got.UnmarshalJSON(w.Bytes()); err != nil {
+				t.Fatal(err)
+			}
+			assertTitles(t, tt.want, got.items)
+		})
+	}
+}
+
+func TestSectionHeader(t *testing.T) {
+	t.Run("header is invalid without autocomplete", func(t *testing.T) {
+		w := testWorkflow(WithArguments("query"))
+		w.AppendSection(NewSection("section").Icon(IconExec()).Items(NewItem().Title("item")))
+
+		got := new(ScriptFilter)
+		if err := got.UnmarshalJSON(w.Bytes()); err != nil {
+			t.Fatal(err)
+		}
+		want := NewItem().Title("section").Icon(IconExec()).Valid(false)
+		if diff := Diff(want, got.items[0]); diff != "" {
+			t.Errorf("-want +got\n%+v", diff)
+		}
+	})
+
+	t.Run("header is not reported by validation", func(t *testing.T) {
+		sf := NewScriptFilter()
+		sf.Items(NewSection("section").header())
+		if errs := sf.Validate(); errs != nil {
+			t.Errorf("unexpected errors %v", errs)
+		}
+	})
+}
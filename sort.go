@@ -0,0 +1,60 @@
+package alfred
+
+import (
+	"sort"
+)
+
+// Sort sorts items by properties in order.
+// Items with the same values keep the current order e.g. fuzzy score order after Filter
+func (w *Workflow) Sort(property ItemProperty, properties ...ItemProperty) *Workflow {
+	w.items = w.items.SortBy(property, properties...)
+	w.eachSection(func(i Items) Items { return i.SortBy(property, properties...) })
+	return w
+}
+
+// SortBy returns items sorted by properties in ascending order.
+// Later properties are used when the former values are equal.
+// The sort is stable so the current order is preserved as a tie-breaker
+func (i Items) SortBy(property ItemProperty, properties ...ItemProperty) Items {
+	props := append([]ItemProperty{property}, properties...)
+
+	type keyed struct {
+		item *Item
+		keys []string
+	}
+	sorted := make([]keyed, len(i))
+	for idx, item := range i {
+		keys := make([]string, len(props))
+		for pidx, p := range props {
+			keys[pidx] = getItemValue(item, p.String())
+		}
+		sorted[idx] = keyed{item: item, keys: keys}
+	}
+
+	sort.SliceStable(sorted, func(a, b int) bool {
+		for pidx := range props {
+			ka, kb := sorted[a].keys[pidx], sorted[b].keys[pidx]
+			if ka != kb {
+				return ka < kb
+			}
+		}
+		return false
+	})
+
+	items := make(Items, len(sorted), cap(i))
+	for idx, s := range sorted {
+		items[idx] = s.item
+	}
+	return items
+}
+
+// SortFunc returns items sorted by less function.
+// The sort is stable so the current order is preserved as a tie-breaker
+func (i Items) SortFunc(less func(a, b *Item) bool) Items {
+	items := make(Items, len(i), cap(i))
+	copy(items, i)
+	sort.SliceStable(items, func(a, b int) bool {
+		return less(items[a], items[b])
+	})
+	return items
+}
@@ -0,0 +1,77 @@
+package alfred
+
+import (
+	"testing"
+)
+
+var items06 = Items{
+	{title: "b", subtitle: "2", arg: []string{"x"}},
+	{title: "a", subtitle: "2", arg: []string{"y"}},
+	{title: "c", subtitle: "1", arg: []string{"x"}},
+	{title: "d", subtitle: "1", arg: []string{"z"}},
+}
+
+func TestItems_SortBy(t *testing.T) {
+	tests := []struct {
+		name       string
+		property   ItemProperty
+		properties []ItemProperty
+		want       []string
+	}{
+		{
+			name:     "by title",
+			property: ItemPropertyTitle,
+			want:     []string{"a", "b", "c", "d"},
+		},
+		{
+			name:     "stable by subtitle",
+			property: ItemPropertySubtitle,
+			want:     []string{"c", "d", "b", "a"},
+		},
+		{
+			name:       "by arg and title",
+			property:   ItemPropertyArg,
+			properties: []ItemProperty{ItemPropertyTitle},
+			want:       []string{"b", "c", "a", "d"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := items06.SortBy(tt.property, tt.properties...)
+			assertTitles(t, tt.want, got)
+		})
+	}
+}
+
+func TestItems_SortFunc(t *testing.T) {
+	t.Run("custom key", func(t *testing.T) {
+		got := items06.SortFunc(func(a, b *Item) bool { return a.title > b.title })
+		assertTitles(t, []string{"d", "c", "b", "a"}, got)
+		// the original items are not changed
+		assertTitles(t, []string{"b", "a", "c", "d"}, items06)
+	})
+}
+
+func TestWorkflow_Sort(t *testing.T) {
+	t.Run("keep fuzzy score order as tie-breaker", func(t *testing.T) {
+		w := testWorkflow().Append(
+			NewItem().Title("xaxbxc").Subtitle("1"),
+			NewItem().Title("abc").Subtitle("1"),
+			NewItem().Title("abcd").Subtitle("0"),
+		)
+		got := w.Filter("abc").Sort(ItemPropertySubtitle).items
+		assertTitles(t, []string{"abcd", "abc", "xaxbxc"}, got)
+	})
+}
+
+func assertTitles(t *testing.T, want []string, items Items) {
+	t.Helper()
+	if len(want) != len(items) {
+		t.Fatalf("want %d items but got %d", len(want), len(items))
+	}
+	for idx, item := range items {
+		if item.title != want[idx] {
+			t.Errorf("items[%d] want %s got %s", idx, want[idx], item.title)
+		}
+	}
+}
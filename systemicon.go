@@ -6,11 +6,109 @@ import (
 
 const (
 	SystemIconPath = "/System/Library/CoreServices/CoreTypes.bundle/Contents/Resources"
-	iconTrash      = "TrashIcon.icns"
-	iconAlerNote   = "AlertNoteIcon.icns"
-	iconCaution    = "AlertCautionBadgeIcon.icns"
-	iconAlertStop  = "AlertStopIcon.icns"
-	iconExec       = "ExecutableBinaryIcon.icns"
+)
+
+// Icon filenames in CoreTypes.bundle. They can be passed to NewSystemIcon
+const (
+	SystemIconAccounts                 = "Accounts.icns"
+	SystemIconActions                  = "Actions.icns"
+	SystemIconAirDrop                  = "AirDrop.icns"
+	SystemIconAlertCaution             = "AlertCautionIcon.icns"
+	SystemIconAlertCautionBadge        = "AlertCautionBadgeIcon.icns"
+	SystemIconAlertNote                = "AlertNoteIcon.icns"
+	SystemIconAlertStop                = "AlertStopIcon.icns"
+	SystemIconAllMyFiles               = "AllMyFiles.icns"
+	SystemIconApplicationsFolder       = "ApplicationsFolderIcon.icns"
+	SystemIconBackwardArrow            = "BackwardArrowIcon.icns"
+	SystemIconBonjour                  = "BonjourIcon.icns"
+	SystemIconBookmark                 = "BookmarkIcon.icns"
+	SystemIconBurnableFolder           = "BurnableFolderIcon.icns"
+	SystemIconBurning                  = "BurningIcon.icns"
+	SystemIconClippingPicture          = "ClippingPicture.icns"
+	SystemIconClippingSound            = "ClippingSound.icns"
+	SystemIconClippingText             = "ClippingText.icns"
+	SystemIconClippingUnknown          = "ClippingUnknown.icns"
+	SystemIconClock                    = "Clock.icns"
+	SystemIconColorSyncProfile         = "ColorSyncProfileIcon.icns"
+	SystemIconConnectTo                = "ConnectToIcon.icns"
+	SystemIconDesktopFolder            = "DesktopFolderIcon.icns"
+	SystemIconDeveloperFolder          = "DeveloperFolderIcon.icns"
+	SystemIconDocumentsFolder          = "DocumentsFolderIcon.icns"
+	SystemIconDownloadsFolder          = "DownloadsFolder.icns"
+	SystemIconDropFolder               = "DropFolderIcon.icns"
+	SystemIconEjectMedia               = "EjectMediaIcon.icns"
+	SystemIconErasing                  = "ErasingIcon.icns"
+	SystemIconEveryone                 = "Everyone.icns"
+	SystemIconExecutableBinary         = "ExecutableBinaryIcon.icns"
+	SystemIconFavoriteItems            = "FavoriteItemsIcon.icns"
+	SystemIconFileVault                = "FileVaultIcon.icns"
+	SystemIconFinder                   = "FinderIcon.icns"
+	SystemIconForwardArrow             = "ForwardArrowIcon.icns"
+	SystemIconFullTrash                = "FullTrashIcon.icns"
+	SystemIconGenericAirDisk           = "GenericAirDiskIcon.icns"
+	SystemIconGenericApplication       = "GenericApplicationIcon.icns"
+	SystemIconGenericDocument          = "GenericDocumentIcon.icns"
+	SystemIconGenericEditionFile       = "GenericEditionFileIcon.icns"
+	SystemIconGenericExtension         = "GenericExtensionIcon.icns"
+	SystemIconGenericFileServer        = "GenericFileServerIcon.icns"
+	SystemIconGenericFolder            = "GenericFolderIcon.icns"
+	SystemIconGenericFont              = "GenericFontIcon.icns"
+	SystemIconGenericNetwork           = "GenericNetworkIcon.icns"
+	SystemIconGenericQuestionMark      = "GenericQuestionMarkIcon.icns"
+	SystemIconGenericSharepoint        = "GenericSharepoint.icns"
+	SystemIconGenericStationery        = "GenericStationeryIcon.icns"
+	SystemIconGenericTimeMachineDisk   = "GenericTimeMachineDiskIcon.icns"
+	SystemIconGenericURL               = "GenericURLIcon.icns"
+	SystemIconGenericWindow            = "GenericWindowIcon.icns"
+	SystemIconGroup                    = "GroupIcon.icns"
+	SystemIconGroupFolder              = "GroupFolder.icns"
+	SystemIconGuestUser                = "GuestUserIcon.icns"
+	SystemIconHelp                     = "HelpIcon.icns"
+	SystemIconHomeFolder               = "HomeFolderIcon.icns"
+	SystemIconInternetLocation         = "InternetLocation.icns"
+	SystemIconKEXT                     = "KEXT.icns"
+	SystemIconKeepArranged             = "KeepArrangedIcon.icns"
+	SystemIconLibraryFolder            = "LibraryFolderIcon.icns"
+	SystemIconLocked                   = "LockedIcon.icns"
+	SystemIconMagnifyingGlass          = "MagnifyingGlassIcon.icns"
+	SystemIconMovieFolder              = "MovieFolderIcon.icns"
+	SystemIconMultipleItems            = "MultipleItemsIcon.icns"
+	SystemIconMusicFolder              = "MusicFolderIcon.icns"
+	SystemIconNetBootVolume            = "NetBootVolume.icns"
+	SystemIconOpenFolder               = "OpenFolderIcon.icns"
+	SystemIconPicturesFolder           = "PicturesFolderIcon.icns"
+	SystemIconPrivateFolderBadge       = "PrivateFolderBadgeIcon.icns"
+	SystemIconProblemReport            = "ProblemReport.icns"
+	SystemIconPublicFolder             = "PublicFolderIcon.icns"
+	SystemIconReadOnlyFolderBadge      = "ReadOnlyFolderBadgeIcon.icns"
+	SystemIconRecentItems              = "RecentItemsIcon.icns"
+	SystemIconServerApplicationsFolder = "ServerApplicationsFolderIcon.icns"
+	SystemIconSitesFolder              = "SitesFolderIcon.icns"
+	SystemIconSmartFolder              = "SmartFolderIcon.icns"
+	SystemIconSync                     = "Sync.icns"
+	SystemIconSystemFolder             = "SystemFolderIcon.icns"
+	SystemIconToolbarAdvanced          = "ToolbarAdvanced.icns"
+	SystemIconToolbarCustomize         = "ToolbarCustomizeIcon.icns"
+	SystemIconToolbarDelete            = "ToolbarDeleteIcon.icns"
+	SystemIconToolbarDesktopFolder     = "ToolbarDesktopFolderIcon.icns"
+	SystemIconToolbarDocumentsFolder   = "ToolbarDocumentsFolderIcon.icns"
+	SystemIconToolbarDownloadsFolder   = "ToolbarDownloadsFolderIcon.icns"
+	SystemIconToolbarFavorites         = "ToolbarFavoritesIcon.icns"
+	SystemIconToolbarInfo              = "ToolbarInfo.icns"
+	SystemIconToolbarMovieFolder       = "ToolbarMovieFolderIcon.icns"
+	SystemIconToolbarMusicFolder       = "ToolbarMusicFolderIcon.icns"
+	SystemIconToolbarPicturesFolder    = "ToolbarPicturesFolderIcon.icns"
+	SystemIconToolbarSitesFolder       = "ToolbarSitesFolderIcon.icns"
+	SystemIconToolbarUtilitiesFolder   = "ToolbarUtilitiesFolderIcon.icns"
+	SystemIconTrash                    = "TrashIcon.icns"
+	SystemIconUnknownFSObject          = "UnknownFSObjectIcon.icns"
+	SystemIconUnlocked                 = "UnlockedIcon.icns"
+	SystemIconUser                     = "UserIcon.icns"
+	SystemIconUserFolder               = "UserFolderIcon.icns"
+	SystemIconUserUnknown              = "UserUnknownIcon.icns"
+	SystemIconUsersFolder              = "UsersFolderIcon.icns"
+	SystemIconUtilitiesFolder          = "UtilitiesFolder.icns"
+	SystemIconVoicesFolder             = "VoicesFolderIcon.icns"
 )
 
 func getIconPath(filename string) string {
@@ -23,9 +121,9 @@ func NewSystemIcon(filename string) *Icon {
 }
 
 var (
-	IconTrash     = func() *Icon { return NewSystemIcon(iconTrash) }
-	IconAlertNote = func() *Icon { return NewSystemIcon(iconAlerNote) }
-	IconCaution   = func() *Icon { return NewSystemIcon(iconCaution) }
-	IconAlertStop = func() *Icon { return NewSystemIcon(iconAlertStop) }
-	IconExec      = func() *Icon { return NewSystemIcon(iconExec) }
+	IconTrash     = func() *Icon { return NewSystemIcon(SystemIconTrash) }
+	IconAlertNote = func() *Icon { return NewSystemIcon(SystemIconAlertNote) }
+	IconCaution   = func() *Icon { return NewSystemIcon(SystemIconAlertCautionBadge) }
+	IconAlertStop = func() *Icon { return NewSystemIcon(SystemIconAlertStop) }
+	IconExec      = func() *Icon { return NewSystemIcon(SystemIconExecutableBinary) }
 )
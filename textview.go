@@ -0,0 +1,239 @@
+package alfred
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// TextViewResponse defines how a new response is added to the text view
+type TextViewResponse string
+
+const (
+	// TextViewResponseReplace replaces the whole text with the new response
+	TextViewResponseReplace TextViewResponse = "replace"
+	// TextViewResponseAppend appends the new response to the text
+	TextViewResponseAppend TextViewResponse = "append"
+	// TextViewResponseReplaceLast replaces the last appended response with the new one
+	TextViewResponseReplaceLast TextViewResponse = "replacelast"
+)
+
+// TextViewScroll defines the scroll position after the text is updated
+type TextViewScroll string
+
+const (
+	TextViewScrollAuto  TextViewScroll = "auto"
+	TextViewScrollStart TextViewScroll = "start"
+	TextViewScrollEnd   TextViewScroll = "end"
+)
+
+// TextViewInputField defines the behaviour of the input field after the text is updated
+type TextViewInputField string
+
+const (
+	TextViewInputFieldClear  TextViewInputField = "clear"
+	TextViewInputFieldSelect TextViewInputField = "select"
+)
+
+// TextView JSON Format for Alfred Text View
+type TextView struct {
+	rerun     Rerun
+	variables Variables
+	response  string
+	footer    string
+	behaviour *Behaviour
+}
+
+// NewTextView creates a new TextView
+func NewTextView() *TextView {
+	return new(TextView)
+}
+
+// Response adds markdown text displayed in the text view
+func (t *TextView) Response(s string) *TextView {
+	t.response = s
+	return t
+}
+
+// Footer adds footer text
+func (t *TextView) Footer(s string) *TextView {
+	t.footer = s
+	return t
+}
+
+// Behaviour adds behaviour of the text view
+func (t *TextView) Behaviour(b *Behaviour) *TextView {
+	t.behaviour = b
+	return t
+}
+
+// Rerun adds rerun interval
+func (t *TextView) Rerun(i Rerun) *TextView {
+	t.rerun = i
+	return t
+}
+
+// Variables adds variables
+func (t *TextView) Variables(vars Variables) *TextView {
+	for k, v := range vars {
+		t.Variable(k, v)
+	}
+	return t
+}
+
+// Variable adds single key/value pair
+func (t *TextView) Variable(k, v string) *TextView {
+	if k == "" {
+		return t
+	}
+	if t.variables == nil {
+		t.variables = make(Variables)
+	}
+	t.variables[k] = v
+	return t
+}
+
+func (t *TextView) Bytes() []byte {
+	res, err := t.MarshalJSON()
+	if err != nil {
+		return fatalError(err)
+	}
+
+	return res
+}
+
+func (t *TextView) String() string {
+	return string(t.Bytes())
+}
+
+func (t *TextView) MarshalJSON() ([]byte, error) {
+	out := t.internal()
+	return json.Marshal(out)
+}
+
+func (t *TextView) UnmarshalJSON(data []byte) error {
+	in := &iTextView{}
+	err := json.Unmarshal(data, in)
+	if err != nil {
+		return err
+	}
+
+	*t = *in.external()
+	return nil
+}
+
+// Behaviour element controls how the text view is updated
+type Behaviour struct {
+	response   TextViewResponse
+	scroll     TextViewScroll
+	inputField TextViewInputField
+}
+
+// NewBehaviour generates new behaviour
+func NewBehaviour() *Behaviour {
+	return new(Behaviour)
+}
+
+// Response adds how the response is added
+func (b *Behaviour) Response(r TextViewResponse) *Behaviour {
+	b.response = r
+	return b
+}
+
+// Scroll adds scroll position
+func (b *Behaviour) Scroll(s TextViewScroll) *Behaviour {
+	b.scroll = s
+	return b
+}
+
+// InputField adds behaviour of the input field
+func (b *Behaviour) InputField(i TextViewInputField) *Behaviour {
+	b.inputField = i
+	return b
+}
+
+type iTextView struct {
+	Rerun     Rerun       `json:"rerun,omitempty"`
+	Variables Variables   `json:"variables,omitempty"`
+	Response  string      `json:"response"`
+	Footer    string      `json:"footer,omitempty"`
+	Behaviour *iBehaviour `json:"behaviour,omitempty"`
+}
+
+type iBehaviour struct {
+	Response   TextViewResponse   `json:"response,omitempty"`
+	Scroll     TextViewScroll     `json:"scroll,omitempty"`
+	InputField TextViewInputField `json:"inputfield,omitempty"`
+}
+
+func (t *TextView) internal() *iTextView {
+	return &iTextView{
+		Rerun:     t.rerun,
+		Variables: t.variables,
+		Response:  t.response,
+		Footer:    t.footer,
+		Behaviour: t.behaviour.internal(),
+	}
+}
+
+func (t *iTextView) external() *TextView {
+	return &TextView{
+		rerun:     t.Rerun,
+		variables: t.Variables,
+		response:  t.Response,
+		footer:    t.Footer,
+		behaviour: t.Behaviour.external(),
+	}
+}
+
+func (b *Behaviour) internal() *iBehaviour {
+	if b == nil {
+		return nil
+	}
+	return &iBehaviour{
+		Response:   b.response,
+		Scroll:     b.scroll,
+		InputField: b.inputField,
+	}
+}
+
+func (b *iBehaviour) external() *Behaviour {
+	if b == nil {
+		return nil
+	}
+	return &Behaviour{
+		response:   b.Response,
+		scroll:     b.Scroll,
+		inputField: b.InputField,
+	}
+}
+
+// writeTextViewTo writes Text View JSON. errors are rendered as markdown
+func (w *Workflow) writeTextViewTo(out io.Writer) (int64, error) {
+	tv := w.textView
+	if len(w.err) > 0 {
+		tv = NewTextView().
+			Response(errorMarkdown(w.err)).
+			Behaviour(NewBehaviour().Response(TextViewResponseReplace))
+	}
+
+	b, err := tv.MarshalJSON()
+	if err != nil {
+		return 0, err
+	}
+	n, err := out.Write(b)
+	return int64(n), err
+}
+
+// errorMarkdown renders error items as headings of title followed by subtitle
+func errorMarkdown(items Items) string {
+	sections := make([]string, 0, len(items))
+	for _, item := range items {
+		s := "### " + item.title
+		if item.subtitle != "" {
+			s += "\n\n" + item.subtitle
+		}
+		sections = append(sections, s)
+	}
+	return strings.Join(sections, "\n\n")
+}
@@ -0,0 +1,114 @@
+package alfred
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestTextView(t *testing.T) {
+	tests := []struct {
+		name     string
+		filepath string
+		textView *TextView
+	}{
+		{
+			name:     "marshal text view",
+			filepath: testFilePath("test_textview.json"),
+			textView: NewTextView().
+				Response("# Title\n\nbody").
+				Footer("footer").
+				Rerun(0.5).
+				Variable("key1", "value1").
+				Behaviour(
+					NewBehaviour().
+						Response(TextViewResponseAppend).
+						Scroll(TextViewScrollEnd).
+						InputField(TextViewInputFieldSelect),
+				),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := os.ReadFile(tt.filepath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := NewTextView()
+			if err := json.Unmarshal(data, want); err != nil {
+				t.Fatal(err)
+			}
+			if diff := Diff(want, tt.textView); diff != "" {
+				t.Errorf("-want +got\n%+v", diff)
+			}
+
+			// marshal/unmarshal test
+			got := NewTextView()
+			if err := got.UnmarshalJSON(tt.textView.Bytes()); err != nil {
+				t.Fatal(err)
+			}
+			if diff := Diff(want, got); diff != "" {
+				t.Errorf("-want +got\n%+v", diff)
+			}
+		})
+	}
+}
+
+func TestWorkflow_TextView(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         []Option
+		fn           func(*Workflow) error
+		initializers []Initializer
+		want         *TextView
+	}{
+		{
+			name: "output text view",
+			fn: func(w *Workflow) error {
+				w.SetTextView(NewTextView().Response("response")).Output()
+				return nil
+			},
+			want: NewTextView().Response("response"),
+		},
+		{
+			name: "output error as markdown",
+			fn: func(w *Workflow) error {
+				w.SetTextView(NewTextView().Response("response"))
+				return errors.New("error message")
+			},
+			want: NewTextView().
+				Response("### error message\n\nPlease check workflow debug log").
+				Behaviour(NewBehaviour().Response(TextViewResponseReplace)),
+		},
+		{
+			name: "output initializer error as markdown with the option",
+			opts: []Option{WithTextView()},
+			fn: func(w *Workflow) error {
+				w.SetTextView(NewTextView().Response("response")).Output()
+				return nil
+			},
+			initializers: []Initializer{new(testInitializer)},
+			want: NewTextView().
+				Response("### initializer error\n\nPlease check workflow debug log").
+				Behaviour(NewBehaviour().Response(TextViewResponseReplace)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outBuf := new(bytes.Buffer)
+			w := testWorkflow(append(tt.opts, WithOutWriter(outBuf))...)
+			w.Run(tt.fn, tt.initializers...)
+
+			got := NewTextView()
+			if err := json.Unmarshal(outBuf.Bytes(), got); err != nil {
+				t.Fatal(err)
+			}
+			if diff := Diff(tt.want, got); diff != "" {
+				t.Errorf("-want +got\n%+v", diff)
+			}
+		})
+	}
+}
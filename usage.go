@@ -0,0 +1,177 @@
+package alfred
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"time"
+)
+
+const (
+	// UsageVariable is the item variable holding the uid of the selected item.
+	// The usage recorder records the uid when the workflow runs with the variable
+	UsageVariable = "go_alfred_usage_uid"
+	// DefaultUsageHalfLife is the default duration after which a score halves
+	DefaultUsageHalfLife = 7 * 24 * time.Hour
+
+	usageFile = "usage.json"
+)
+
+// Usage records selections of items by uid and scores them by frequency and recency
+type Usage struct {
+	icache   internalCacher
+	halfLife time.Duration
+	entries  map[string]*UsageEntry
+}
+
+// UsageEntry is the selection history of an item
+type UsageEntry struct {
+	Count    int       `json:"count"`
+	Score    float64   `json:"score"`
+	LastUsed time.Time `json:"last_used"`
+}
+
+// WithUsageRecorder records the selected item on the next run.
+// Items having uid are tagged with UsageVariable on output and
+// the recorder stores the uid passed back by Alfred into GetDataDir()
+func WithUsageRecorder() Option {
+	return func(wf *Workflow) {
+		wf.customEnvs.usage = true
+	}
+}
+
+// Usage returns the selection history of the workflow stored in GetDataDir()
+func (w *Workflow) Usage() *Usage {
+	if w.usage == nil {
+		w.usage = &Usage{
+			icache: &cache{
+				dir:  GetDataDir(),
+				file: usageFile,
+			},
+			halfLife: DefaultUsageHalfLife,
+		}
+	}
+	return w.usage
+}
+
+// HalfLife changes the duration after which a score halves
+func (u *Usage) HalfLife(d time.Duration) *Usage {
+	if d > 0 {
+		u.halfLife = d
+	}
+	return u
+}
+
+// Record increments the score of the uid and saves the history
+func (u *Usage) Record(uid string) error {
+	if uid == "" {
+		return nil
+	}
+	if err := u.load(); err != nil {
+		return err
+	}
+
+	t := now()
+	e, ok := u.entries[uid]
+	if !ok {
+		e = &UsageEntry{}
+		u.entries[uid] = e
+	}
+	e.Score = u.decay(e, t) + 1
+	e.Count++
+	e.LastUsed = t
+	return u.icache.store(u.entries)
+}
+
+// Score returns the decayed score of the uid. Unknown uids score 0
+func (u *Usage) Score(uid string) float64 {
+	if uid == "" {
+		return 0
+	}
+	if err := u.load(); err != nil {
+		return 0
+	}
+	e, ok := u.entries[uid]
+	if !ok {
+		return 0
+	}
+	return u.decay(e, now())
+}
+
+// Entries returns a copy of the selection history keyed by uid
+func (u *Usage) Entries() (map[string]UsageEntry, error) {
+	if err := u.load(); err != nil {
+		return nil, err
+	}
+	ret := make(map[string]UsageEntry, len(u.entries))
+	for uid, e := range u.entries {
+		ret[uid] = *e
+	}
+	return ret, nil
+}
+
+// Export writes the selection history as JSON
+func (u *Usage) Export(out io.Writer) error {
+	entries, err := u.Entries()
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(out).Encode(entries)
+}
+
+// Reset removes the selection history
+func (u *Usage) Reset() error {
+	u.entries = make(map[string]*UsageEntry)
+	return u.icache.clear()
+}
+
+func (u *Usage) load() error {
+	if u.entries != nil {
+		return nil
+	}
+
+	entries := make(map[string]*UsageEntry)
+	if err := u.icache.load(&entries); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to load usage: %w", err)
+	}
+	u.entries = entries
+	return nil
+}
+
+// decay returns the score of the entry at t
+func (u *Usage) decay(e *UsageEntry, t time.Time) float64 {
+	elapsed := t.Sub(e.LastUsed)
+	if elapsed <= 0 {
+		return e.Score
+	}
+	return e.Score * math.Pow(0.5, float64(elapsed)/float64(u.halfLife))
+}
+
+// WithUsageBoost adds the usage scores multiplied by weight to the scores of matched items.
+// If the query is empty, items are sorted by the usage scores
+func WithUsageBoost(u *Usage, weight int) FilterOption {
+	return func(c *filterConfig) {
+		c.boost = func(item *Item) int {
+			return int(u.Score(item.uid) * float64(weight))
+		}
+	}
+}
+
+type usageRecorder struct{}
+
+// Condition returns true if the workflow is invoked with the selected uid
+func (*usageRecorder) Condition(_ *Workflow) bool {
+	return os.Getenv(UsageVariable) != ""
+}
+
+// Initialize records the selected uid
+func (*usageRecorder) Initialize(w *Workflow) error {
+	uid := os.Getenv(UsageVariable)
+	if err := w.Usage().Record(uid); err != nil {
+		// recording must not break the workflow
+		w.sLogger().Warnln("failed to record usage:", err)
+	}
+	return nil
+}
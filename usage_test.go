@@ -0,0 +1,172 @@
+package alfred
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func setNow(t *testing.T, tm time.Time) {
+	t.Helper()
+	orig := now
+	now = func() time.Time { return tm }
+	t.Cleanup(func() { now = orig })
+}
+
+func testUsage(t *testing.T) *Usage {
+	t.Helper()
+	u := testWorkflow().Usage()
+	if err := u.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = u.Reset() })
+	return u
+}
+
+func TestUsage_RecordAndScore(t *testing.T) {
+	base := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	setNow(t, base)
+	u := testUsage(t).HalfLife(time.Hour)
+
+	for i := 0; i < 2; i++ {
+		if err := u.Record("uid-a"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := u.Record("uid-b"); err != nil {
+		t.Fatal(err)
+	}
+	if got := u.Score("uid-a"); got != 2 {
+		t.Errorf("want 2 got %v", got)
+	}
+	if got := u.Score("unknown"); got != 0 {
+		t.Errorf("want 0 got %v", got)
+	}
+
+	setNow(t, base.Add(time.Hour))
+	if got := u.Score("uid-a"); math.Abs(got-1) > 1e-9 {
+		t.Errorf("score should be halved: got %v", got)
+	}
+
+	// history is persisted
+	reloaded := testWorkflow().Usage()
+	entries, err := reloaded.Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entries["uid-a"].Count != 2 || entries["uid-b"].Count != 1 {
+		t.Errorf("unexpected entries %+v", entries)
+	}
+}
+
+func TestUsage_ExportAndReset(t *testing.T) {
+	setNow(t, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	u := testUsage(t)
+	if err := u.Record("uid-a"); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := u.Export(buf); err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]UsageEntry{}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["uid-a"].Count != 1 {
+		t.Errorf("unexpected export %s", buf.String())
+	}
+
+	if err := u.Reset(); err != nil {
+		t.Fatal(err)
+	}
+	if s := testWorkflow().Usage().Score("uid-a"); s != 0 {
+		t.Errorf("history should be reset: got %v", s)
+	}
+}
+
+func TestItems_FilterWithUsageBoost(t *testing.T) {
+	setNow(t, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	u := testUsage(t)
+	if err := u.Record("c"); err != nil {
+		t.Fatal(err)
+	}
+
+	items := Items{
+		NewItem().Title("go").UID("a"),
+		NewItem().Title("go-alfred").UID("b"),
+		NewItem().Title("golang").UID("c"),
+		NewItem().Title("rust").UID("d"),
+	}
+	opts := []FilterOption{WithMatcher(PrefixMatcher()), WithUsageBoost(u, 1000)}
+
+	got := items.Filter("go", opts...)
+	want := []string{"golang", "go", "go-alfred"}
+	if !reflect.DeepEqual(titles(got), want) {
+		t.Errorf("want %v got %v", want, titles(got))
+	}
+
+	got = items.Filter("", opts...)
+	want = []string{"golang", "go", "go-alfred", "rust"}
+	if !reflect.DeepEqual(titles(got), want) {
+		t.Errorf("want %v got %v", want, titles(got))
+	}
+}
+
+func TestUsageRecorder(t *testing.T) {
+	setNow(t, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	testUsage(t)
+
+	t.Setenv(UsageVariable, "selected")
+	wf := testWorkflow(WithUsageRecorder())
+	if err := wf.OnInitialize(); err != nil {
+		t.Fatal(err)
+	}
+	if s := testWorkflow().Usage().Score("selected"); s != 1 {
+		t.Errorf("want 1 got %v", s)
+	}
+
+	wf.Append(
+		NewItem().Title("with uid").UID("x"),
+		NewItem().Title("without uid"),
+	)
+	got := new(ScriptFilter)
+	if err := got.UnmarshalJSON(wf.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if v := got.items[0].variables[UsageVariable]; v != "x" {
+		t.Errorf("want x got %q", v)
+	}
+	if _, ok := got.items[1].variables[UsageVariable]; ok {
+		t.Error("item without uid should not be tagged")
+	}
+	if _, ok := wf.items[0].variables[UsageVariable]; ok {
+		t.Error("variables of the item should not be modified")
+	}
+}
+
+func TestUsageRecorder_WithInitializers(t *testing.T) {
+	setNow(t, time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+	testUsage(t)
+
+	t.Setenv(UsageVariable, "selected")
+	for name, opts := range map[string][]Option{
+		"recorder first":     {WithUsageRecorder(), WithInitializers()},
+		"initializers first": {WithInitializers(), WithUsageRecorder()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			testUsage(t)
+			wf := testWorkflow(opts...)
+			if err := wf.OnInitialize(); err != nil {
+				t.Fatal(err)
+			}
+			if s := testWorkflow().Usage().Score("selected"); s != 1 {
+				t.Errorf("want 1 got %v", s)
+			}
+		})
+	}
+}
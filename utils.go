@@ -4,6 +4,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"golang.org/x/text/unicode/norm"
 )
@@ -12,6 +13,7 @@ var (
 	// wrapper for tests
 	osExit = os.Exit
 	tmpDir = os.TempDir()
+	now    = time.Now
 )
 
 // UnsetVariable unsets variable with key for existing Workflow
@@ -24,9 +26,10 @@ func GetItems(w *Workflow) Items {
 	return w.items
 }
 
-// ResetItems resets all items including EmptyWarning(), SetSystemInfo()
+// ResetItems resets all items including sections, EmptyWarning(), SetSystemInfo()
 func ResetItems(w *Workflow) {
 	w.items = Items{}
+	w.sections = nil
 	w.system = Items{}
 	w.warn = Items{}
 	w.err = Items{}
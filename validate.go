@@ -0,0 +1,125 @@
+package alfred
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// MinRerun is the minimum interval Alfred accepts for rerun
+	MinRerun Rerun = 0.1
+	// MaxRerun is the maximum interval Alfred accepts for rerun
+	MaxRerun Rerun = 5.0
+)
+
+// ValidationError describes a problem of ScriptFilter JSON
+type ValidationError struct {
+	// Index is the index of the item. -1 means the top level of ScriptFilter
+	Index  int
+	Field  string
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Index < 0 {
+		return fmt.Sprintf("%s: %s", e.Field, e.Reason)
+	}
+	return fmt.Sprintf("items[%d].%s: %s", e.Index, e.Field, e.Reason)
+}
+
+// ValidationErrors is a list of problems found by Validate
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for idx, err := range e {
+		msgs[idx] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Validate returns problems which make Alfred reject or misbehave the JSON.
+// It returns nil if no problem is found
+func (s *ScriptFilter) Validate() ValidationErrors {
+	return s.validate(s.items, func(item *Item) string { return item.uid })
+}
+
+// validate validates the script filter with items and their uids returned by uid
+func (s *ScriptFilter) validate(items Items, uid func(*Item) string) ValidationErrors {
+	var errs ValidationErrors
+	add := func(idx int, field, format string, a ...interface{}) {
+		errs = append(errs, &ValidationError{
+			Index:  idx,
+			Field:  field,
+			Reason: fmt.Sprintf(format, a...),
+		})
+	}
+
+	if s.rerun != 0 && (s.rerun < MinRerun || s.rerun > MaxRerun) {
+		add(-1, "rerun", "must be between %v and %v but got %v", MinRerun, MaxRerun, s.rerun)
+	}
+	if err := s.cache.Validate(); err != nil {
+		add(-1, "cache", "%s", err)
+	}
+
+	uids := make(map[string]int)
+	for idx, item := range items {
+		if item.title == "" {
+			add(idx, "title", "is empty")
+		}
+		for key := range item.mods {
+			if err := key.Validate(); err != nil {
+				add(idx, "mods", "%s", err)
+			}
+		}
+		if item.icon != nil && !isKnownIconType(item.icon.typ) {
+			add(idx, "icon.type", "unknown type %q", item.icon.typ)
+		}
+		if id := uid(item); id != "" {
+			if first, ok := uids[id]; ok {
+				add(idx, "uid", "%q is duplicated with items[%d]", id, first)
+			} else {
+				uids[id] = idx
+			}
+		}
+		// a section header does nothing intentionally
+		if item.valid != nil && !*item.valid && item.autocomplete == "" && !item.header {
+			add(idx, "valid", "invalid item has no autocomplete so that actioning it does nothing")
+		}
+	}
+
+	return errs
+}
+
+// Validate returns problems of items appended to the workflow and its sections as they are output after Dedupe.
+// Indexes of headers and items of sections follow the items of the workflow.
+// System information and empty warnings are not validated.
+// uids derived by OrderingFillUID are validated as they are output
+func (w *Workflow) Validate() ValidationErrors {
+	items, groups := w.dedupedItems()
+	for idx, s := range w.sections {
+		if len(groups[idx]) == 0 && !s.showEmpty {
+			continue
+		}
+		items = append(items[:len(items):len(items)], s.header())
+		items = append(items, groups[idx]...)
+	}
+	return w.ScriptFilter.validate(items, w.outputUID)
+}
+
+// outputUID returns the uid of the item on output
+func (w *Workflow) outputUID(item *Item) string {
+	if item.uid == "" && w.customEnvs.ordering.has(OrderingFillUID) {
+		return deriveUID(item)
+	}
+	return item.uid
+}
+
+func isKnownIconType(typ string) bool {
+	switch typ {
+	case "", IconTypeFileIcon, IconTypeFileType:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,111 @@
+package alfred
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/konoui/go-alfred/env"
+)
+
+func TestScriptFilter_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(sf *ScriptFilter)
+		want  ValidationErrors
+	}{
+		{
+			name: "valid script filter",
+			setup: func(sf *ScriptFilter) {
+				sf.Items(items05...)
+				sf.Rerun(1)
+				sf.CacheControl(NewCacheControl(60))
+			},
+		},
+		{
+			name: "rerun and cache are out of range",
+			setup: func(sf *ScriptFilter) {
+				sf.Rerun(10)
+				sf.CacheControl(NewCacheControl(1))
+			},
+			want: ValidationErrors{
+				{Index: -1, Field: "rerun"},
+				{Index: -1, Field: "cache"},
+			},
+		},
+		{
+			name: "invalid items",
+			setup: func(sf *ScriptFilter) {
+				sf.Items(
+					NewItem().UID("uid"),
+					NewItem().Title("title").UID("uid").Mod(ModKey("command"), NewMod()),
+					NewItem().Title("title").Icon(NewIcon().Type("unknown")),
+					NewItem().Title("title").Valid(false),
+				)
+			},
+			want: ValidationErrors{
+				{Index: 0, Field: "title"},
+				{Index: 1, Field: "mods"},
+				{Index: 1, Field: "uid"},
+				{Index: 2, Field: "icon.type"},
+				{Index: 3, Field: "valid"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sf := NewScriptFilter()
+			tt.setup(sf)
+			got := sf.Validate()
+			if len(got) != len(tt.want) {
+				t.Fatalf("want %d errors but got %d: %v", len(tt.want), len(got), got)
+			}
+			for idx, err := range got {
+				if err.Index != tt.want[idx].Index || err.Field != tt.want[idx].Field {
+					t.Errorf("want items[%d].%s but got %v", tt.want[idx].Index, tt.want[idx].Field, err)
+				}
+			}
+		})
+	}
+}
+
+func TestWorkflow_Validate(t *testing.T) {
+	t.Run("validate sections", func(t *testing.T) {
+		w := testWorkflow().Append(NewItem().Title("item").UID("uid"))
+		w.AppendSection(
+			NewSection("section").Items(
+				NewItem().UID("section"),
+				NewItem().Title("duplicate").UID("uid"),
+			),
+			NewSection("hidden"),
+		)
+
+		got := w.Validate()
+		want := ValidationErrors{
+			{Index: 2, Field: "title"},
+			{Index: 3, Field: "uid"},
+		}
+		if len(got) != len(want) {
+			t.Fatalf("want %d errors but got %d: %v", len(want), len(got), got)
+		}
+		for idx, err := range got {
+			if err.Index != want[idx].Index || err.Field != want[idx].Field {
+				t.Errorf("want items[%d].%s but got %v", want[idx].Index, want[idx].Field, err)
+			}
+		}
+	})
+}
+
+func TestWorkflow_OutputValidationLog(t *testing.T) {
+	t.Run("log problems in debug mode", func(t *testing.T) {
+		t.Setenv(env.KeyWorkflowDebug, "true")
+		errBuf := new(bytes.Buffer)
+		wf := testWorkflow(WithLogWriter(errBuf))
+		wf.Append(NewItem()).Output()
+
+		want := "invalid script filter: items[0].title: is empty"
+		if got := errBuf.String(); !strings.Contains(got, want) {
+			t.Errorf("want: %s\ngot: %s", want, got)
+		}
+	})
+}
@@ -0,0 +1,57 @@
+package alfred
+
+import (
+	"path/filepath"
+	"time"
+)
+
+// valueCacheDir is the subdirectory of GetCacheDir() for value caches
+// so that their files do not collide with ones of item caches e.g. "foo.json" and "x.index.gob"
+const valueCacheDir = "values"
+
+// ValueCache is a typed cache of arbitrary data stored in a subdirectory of GetCacheDir()
+type ValueCache[T any] struct {
+	icache internalCacher
+	maxAge time.Duration
+}
+
+// NewValueCache returns a cache of T for the key. JSONCodec is used if codec is nil.
+// An empty key returns a cache which is always expired
+func NewValueCache[T any](key string, codec Codec) *ValueCache[T] {
+	if key == "" {
+		return &ValueCache[T]{icache: newNilCache()}
+	}
+	c := newCache(key, codec)
+	c.dir = filepath.Join(c.dir, valueCacheDir)
+	return &ValueCache[T]{icache: c}
+}
+
+// MaxAge sets the time to live of the cached data
+func (c *ValueCache[T]) MaxAge(age time.Duration) *ValueCache[T] {
+	c.maxAge = age
+	return c
+}
+
+// Load returns the cached data. ErrCacheExpired is returned if the data is older than MaxAge
+func (c *ValueCache[T]) Load() (T, error) {
+	var v T
+	if c.icache.expired(c.maxAge) {
+		return v, ErrCacheExpired
+	}
+
+	if err := c.icache.load(&v); err != nil {
+		var zero T
+		return zero, err
+	}
+	return v, nil
+}
+
+// Store saves v into the cache atomically
+func (c *ValueCache[T]) Store(v T) error {
+	return c.icache.store(v)
+}
+
+// Clear removes the cached data
+func (c *ValueCache[T]) Clear() error {
+	return c.icache.clear()
+}
@@ -0,0 +1,120 @@
+package alfred
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testValue struct {
+	Token  string
+	Scopes []string
+	Expiry time.Time
+}
+
+func TestValueCache(t *testing.T) {
+	want := testValue{
+		Token:  "token",
+		Scopes: []string{"repo", "user"},
+		Expiry: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	tests := []struct {
+		name  string
+		codec Codec
+	}{
+		{name: "default", codec: nil},
+		{name: "json", codec: JSONCodec},
+		{name: "gob", codec: GobCodec},
+		{name: "gzip json", codec: GzipJSONCodec},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewValueCache[testValue]("test-value", tt.codec)
+			t.Cleanup(func() { _ = c.Clear() })
+			if err := c.Store(want); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := NewValueCache[testValue]("test-value", tt.codec).MaxAge(time.Minute).Load()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(want, got) {
+				t.Errorf("want %+v got %+v", want, got)
+			}
+
+			if _, err := c.MaxAge(0).Load(); !errors.Is(err, ErrCacheExpired) {
+				t.Errorf("want %v got %v", ErrCacheExpired, err)
+			}
+
+			if err := c.Clear(); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := c.MaxAge(time.Minute).Load(); !errors.Is(err, ErrCacheExpired) {
+				t.Errorf("cleared cache should be expired: got %v", err)
+			}
+		})
+	}
+}
+
+func TestValueCache_EmptyKey(t *testing.T) {
+	c := NewValueCache[map[string]int]("", nil)
+	if err := c.Store(map[string]int{"a": 1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.MaxAge(time.Hour).Load(); !errors.Is(err, ErrCacheExpired) {
+		t.Errorf("want %v got %v", ErrCacheExpired, err)
+	}
+}
+
+func TestValueCache_StoreError(t *testing.T) {
+	tests := []struct {
+		name  string
+		codec Codec
+		value any
+	}{
+		{name: "json", codec: JSONCodec, value: make(chan int)},
+		{name: "gob", codec: GobCodec, value: func() {}},
+		{name: "gzip json", codec: GzipJSONCodec, value: make(chan int)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewValueCache[any]("test-value-error", tt.codec)
+			t.Cleanup(func() { _ = c.Clear() })
+			if err := c.Store(tt.value); err == nil {
+				t.Fatal("Store should return an encode error")
+			}
+			if path := c.icache.(*cache).path(); PathExists(path) {
+				t.Errorf("%s should not be created", path)
+			}
+		})
+	}
+}
+
+func TestValueCache_DoesNotCollideWithItemCache(t *testing.T) {
+	wf := testWorkflow()
+	wf.Append(NewItem().Title("item"))
+	items := wf.Cache("test-collision")
+	t.Cleanup(func() { _ = items.Clear() })
+	if err := items.Store(); err != nil {
+		t.Fatal(err)
+	}
+
+	values := NewValueCache[map[string]string]("test-collision", JSONCodec)
+	t.Cleanup(func() { _ = values.Clear() })
+	if err := values.Store(map[string]string{"k": "v"}); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := testWorkflow()
+	if err := loaded.Cache("test-collision").MaxAge(time.Minute).Load(); err != nil {
+		t.Fatal(err)
+	}
+	if got := titles(loaded.items); !reflect.DeepEqual(got, []string{"item"}) {
+		t.Errorf("item cache should not be overwritten: got %v", got)
+	}
+	if got, err := values.MaxAge(time.Minute).Load(); err != nil || got["k"] != "v" {
+		t.Errorf("unexpected value %v: %v", got, err)
+	}
+}
@@ -1,6 +1,8 @@
 package alfred
 
 import (
+	"bufio"
+	"bytes"
 	"fmt"
 	"io"
 	"os"
@@ -15,11 +17,17 @@ type Workflow struct {
 	warn       Items
 	err        Items
 	system     Items
+	sections   []*Section
+	runScript  *RunScriptOutput
+	textView   *TextView
 	markers    markers
 	streams    *streams
 	logger     *logger
 	updater    Updater
 	actions    []Initializer
+	commands   []*Command
+	usage      *Usage
+	index      *SearchIndex
 	customEnvs *customEnvs
 	args       []string
 }
@@ -42,7 +50,13 @@ type logger struct {
 }
 
 type customEnvs struct {
-	maxResults int
+	maxResults   int
+	pagination   bool
+	ordering     OrderingPolicy
+	dedupe       *DedupeStrategy
+	usage        bool
+	searchIndex  bool
+	indexFolding Folding
 }
 
 // Option is type for workflow configurations
@@ -188,7 +202,12 @@ func (w *Workflow) LogWriter() io.Writer {
 	return w.streams.log
 }
 
+// Args returns normalized input args. A page token is removed if pagination is enabled
 func (w *Workflow) Args() []string {
+	if w.customEnvs.pagination {
+		args, _ := splitPageToken(w.args)
+		return args
+	}
 	return w.args
 }
 
@@ -216,10 +235,17 @@ func (w *Workflow) Variable(k, v string) *Workflow {
 	return w
 }
 
+// CacheControl sets cache object for ScriptFilter
+func (w *Workflow) CacheControl(c *CacheControl) *Workflow {
+	w.ScriptFilter.CacheControl(c)
+	return w
+}
+
 // Clear items of ScriptFilters
 // Set* is not clear
 func (w *Workflow) Clear() *Workflow {
 	w.ScriptFilter.Clear()
+	w.sections = nil
 	w.err = Items{}
 	return w
 }
@@ -246,49 +272,87 @@ func (w *Workflow) SetSystemInfo(i *Item) *Workflow {
 	return w
 }
 
-func (w *Workflow) Bytes() []byte {
-	savedStdItems := make(Items, len(w.items), cap(w.items))
-	savedErrItems := make(Items, len(w.err), cap(w.err))
-	copy(savedStdItems, w.items)
-	copy(savedErrItems, w.err)
-	defer func() {
-		w.items = savedStdItems
-		w.err = savedErrItems
-	}()
-
-	if len(w.err) > 0 {
-		items := w.err
-		w.Clear()
-		w.Items(items...)
-		return w.ScriptFilter.Bytes()
+// WithRunScriptOutput outputs Run Script JSON format from the start so that
+// errors before SetRunScriptOutput e.g. on initializers are output as plain text
+func WithRunScriptOutput() Option {
+	return func(wf *Workflow) {
+		wf.SetRunScriptOutput(NewRunScriptOutput())
 	}
+}
 
-	if w.isLimited() {
-		w.items = savedStdItems[:w.customEnvs.maxResults]
+// SetRunScriptOutput switches the output to Run Script JSON format instead of ScriptFilter.
+// Errors are output as plain text in this mode
+func (w *Workflow) SetRunScriptOutput(o *RunScriptOutput) *Workflow {
+	w.runScript = o
+	w.textView = nil
+	return w
+}
+
+// WithTextView outputs Text View JSON format from the start so that
+// errors before SetTextView e.g. on initializers are rendered as markdown
+func WithTextView() Option {
+	return func(wf *Workflow) {
+		wf.SetTextView(NewTextView())
 	}
+}
 
-	if len(w.system) > 0 {
-		if w.IsEmpty() {
-			w.Clear()
-			w.Items(w.system...)
-			w.Items(w.warn...)
-			return w.ScriptFilter.Bytes()
-		}
+// SetTextView switches the output to Text View JSON format instead of ScriptFilter.
+// Errors are rendered as markdown in this mode
+func (w *Workflow) SetTextView(t *TextView) *Workflow {
+	w.textView = t
+	w.runScript = nil
+	return w
+}
 
-		items := w.items
-		w.Clear()
-		w.Items(w.system...)
-		w.Items(items...)
-		return w.ScriptFilter.Bytes()
+func (w *Workflow) Bytes() []byte {
+	buf := new(bytes.Buffer)
+	if _, err := w.WriteTo(buf); err != nil {
+		return fatalError(err)
 	}
+	return buf.Bytes()
+}
 
-	if w.IsEmpty() {
-		w.Clear()
-		w.Items(w.warn...)
-		return w.ScriptFilter.Bytes()
+// WriteTo writes JSON of the workflow to out item by item.
+// Unlike Bytes, whole JSON is not built in memory and items are not copied
+func (w *Workflow) WriteTo(out io.Writer) (int64, error) {
+	if w.runScript != nil {
+		return w.writeRunScriptTo(out)
+	}
+	if w.textView != nil {
+		return w.writeTextViewTo(out)
 	}
+	return w.ScriptFilter.writeTo(out, w.customEnvs.ordering.has(OrderingPreSorted), w.outputItems()...)
+}
 
-	return w.ScriptFilter.Bytes()
+// outputItems returns items to output in order.
+// The returned value shares underlying arrays with the workflow items
+func (w *Workflow) outputItems() []itemGroup {
+	if len(w.err) > 0 {
+		return []itemGroup{{items: w.err}}
+	}
+
+	items, groups := w.dedupedItems()
+	items, more := w.pagedItems(items)
+	sections := w.sectionItems(groups)
+	ret := make([]itemGroup, 0, 3+len(sections))
+	if len(w.system) > 0 {
+		ret = append(ret, itemGroup{items: w.system})
+	}
+	if len(items) == 0 && len(sections) == 0 {
+		return append(ret, itemGroup{items: w.warn})
+	}
+	ret = append(ret, itemGroup{
+		items:    items,
+		fillUID:  w.customEnvs.ordering.has(OrderingFillUID),
+		tagUsage: w.customEnvs.usage,
+	})
+	for _, s := range sections {
+		ret = append(ret, itemGroup{items: s, tagUsage: w.customEnvs.usage})
+	}
+	if more != nil {
+		ret = append(ret, itemGroup{items: Items{more}})
+	}
+	return ret
 }
 
 // String show workflow outputs as JSON
@@ -315,7 +379,16 @@ func (w *Workflow) Output() *Workflow {
 		return w
 	}
 	defer w.markDone()
-	fmt.Fprintln(w.streams.out, w.String())
+	w.logValidationErrors()
+	bw := bufio.NewWriter(w.streams.out)
+	if _, err := w.WriteTo(bw); err != nil {
+		w.sLogger().Errorln("failed to output script filter:", err)
+		_, _ = bw.Write(fatalError(err))
+	}
+	fmt.Fprintln(bw)
+	if err := bw.Flush(); err != nil {
+		w.sLogger().Errorln("failed to output script filter:", err)
+	}
 	return w
 }
 
@@ -323,15 +396,6 @@ func (w *Workflow) markDone() {
 	w.markers.outputDone = true
 }
 
-func (w *Workflow) isLimited() bool {
-	// if maxResults equal 0, this means unlimited
-	limit := w.customEnvs.maxResults
-	if limit > 0 && len(w.items) > limit {
-		return true
-	}
-	return false
-}
-
 func (w *Workflow) syncLogger() {
 	if IsDebugEnabled() {
 		w.logger.level = LogLevelDebug
@@ -2,6 +2,8 @@ package alfred
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
 	"io"
 	"os"
 	"strings"
@@ -178,6 +180,28 @@ func TestOutput(t *testing.T) {
 	}
 }
 
+func TestOutput_InvalidItem(t *testing.T) {
+	outBuf := new(bytes.Buffer)
+	awf := testWorkflow(WithOutWriter(outBuf))
+	awf.Append(
+		NewItem().Title("ok"),
+		NewItem().Title("invalid mod").Mod(ModKey("cmd+shfit"), NewMod().Subtitle("mod")),
+	)
+	awf.Output()
+
+	got := map[string]interface{}{}
+	if err := json.Unmarshal(outBuf.Bytes(), &got); err != nil {
+		t.Fatalf("output should be valid JSON: %v\n%s", err, outBuf.String())
+	}
+	items, ok := got["items"].([]interface{})
+	if !ok || len(items) != 1 {
+		t.Fatalf("only the fatal error item should be output: %s", outBuf.String())
+	}
+	if title := items[0].(map[string]interface{})["title"]; title == "ok" {
+		t.Errorf("partial items should not be output: %s", outBuf.String())
+	}
+}
+
 func TestWorkflow_Clear(t *testing.T) {
 	t.Run("clear item", func(t *testing.T) {
 		want := NewWorkflow().Bytes()
@@ -206,3 +230,83 @@ func TestWorkflow_Fatal(t *testing.T) {
 		}
 	})
 }
+
+func benchmarkItems(n int) Items {
+	items := make(Items, n)
+	for i := range items {
+		items[i] = NewItem().
+			Title(fmt.Sprintf("title%d", i)).
+			Subtitle(fmt.Sprintf("subtitle%d", i)).
+			Arg(fmt.Sprintf("arg%d", i)).
+			UID(fmt.Sprintf("uid%d", i)).
+			Icon(IconExec())
+	}
+	return items
+}
+
+// copyBasedBytes is the former Workflow.Bytes which copies items and builds whole JSON in memory.
+// It is kept as the baseline of BenchmarkWorkflowWriteTo
+func copyBasedBytes(w *Workflow) []byte {
+	savedStdItems := make(Items, len(w.items), cap(w.items))
+	savedErrItems := make(Items, len(w.err), cap(w.err))
+	copy(savedStdItems, w.items)
+	copy(savedErrItems, w.err)
+	defer func() {
+		w.items = savedStdItems
+		w.err = savedErrItems
+	}()
+
+	if len(w.err) > 0 {
+		items := w.err
+		w.ScriptFilter.Clear()
+		w.Items(items...)
+		return w.ScriptFilter.Bytes()
+	}
+
+	if limit := w.customEnvs.maxResults; limit > 0 && len(w.items) > limit {
+		w.items = savedStdItems[:limit]
+	}
+
+	if len(w.system) > 0 {
+		if w.IsEmpty() {
+			w.ScriptFilter.Clear()
+			w.Items(w.system...)
+			w.Items(w.warn...)
+			return w.ScriptFilter.Bytes()
+		}
+
+		items := w.items
+		w.ScriptFilter.Clear()
+		w.Items(w.system...)
+		w.Items(items...)
+		return w.ScriptFilter.Bytes()
+	}
+
+	if w.IsEmpty() {
+		w.ScriptFilter.Clear()
+		w.Items(w.warn...)
+		return w.ScriptFilter.Bytes()
+	}
+
+	return w.ScriptFilter.Bytes()
+}
+
+func BenchmarkScriptFilterBytes(b *testing.B) {
+	wf := testWorkflow().Append(benchmarkItems(20000)...)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := io.Discard.Write(copyBasedBytes(wf)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkWorkflowWriteTo(b *testing.B) {
+	wf := testWorkflow().Append(benchmarkItems(20000)...)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := wf.WriteTo(io.Discard); err != nil {
+			b.Fatal(err)
+		}
+	}
+}